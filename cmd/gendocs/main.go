@@ -0,0 +1,240 @@
+// Command gendocs renders the rule catalogue and the tag reference of the
+// README and the tag snippets of the VS Code snippets file from the
+// categories and supported tags of the rules, so the documentation and the
+// completion cannot drift from the code.
+//
+//	gendocs [-readme README.md] [-snippets vscode/threagile.code-snippets] [-check]
+//
+// The generated parts sit between <!-- generated:<name> --> and
+// <!-- end generated:<name> --> markers in the README and between
+// // generated:<name> and // end generated:<name> comments in the snippets,
+// everything else in the files is left alone. With -check nothing is written
+// and the exit code is 1 when a file is not up to date. It runs with the
+// default settings of the rules.
+package main
+
+//go:generate go run . -readme ../../README.md -snippets ../../vscode/threagile.code-snippets
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/Otyg/threagile-rules/internal/rulekit"
+	"github.com/Otyg/threagile-rules/rules"
+	"github.com/threagile/threagile/model"
+)
+
+const (
+	exitOk = iota
+	exitOutdated
+	exitError
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stderr))
+}
+
+func run(args []string, stderr io.Writer) int {
+	flags := flag.NewFlagSet("gendocs", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	readme := flags.String("readme", "README.md", "markdown file holding the generated sections")
+	snippets := flags.String("snippets", "vscode/threagile.code-snippets", "VS Code snippets file holding the generated tag snippets")
+	check := flags.Bool("check", false, "only report whether the files are up to date")
+	if err := flags.Parse(args); err != nil {
+		return exitError
+	}
+	tags, err := collectTags(rules.All())
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return exitError
+	}
+	files := []struct {
+		name     string
+		generate func([]byte) ([]byte, error)
+	}{
+		{*readme, func(document []byte) ([]byte, error) {
+			return generateReadme(document, rules.All(), tags)
+		}},
+		{*snippets, func(document []byte) ([]byte, error) {
+			return generateSnippets(document, rules.All(), tags)
+		}},
+	}
+	code := exitOk
+	for _, file := range files {
+		current, err := ioutil.ReadFile(file.name)
+		if err != nil {
+			fmt.Fprintln(stderr, err)
+			return exitError
+		}
+		generated, err := file.generate(current)
+		if err != nil {
+			fmt.Fprintf(stderr, "%s: %v\n", file.name, err)
+			return exitError
+		}
+		if bytes.Equal(current, generated) {
+			continue
+		}
+		if *check {
+			fmt.Fprintf(stderr, "%s is out of date, run go generate ./...\n", file.name)
+			code = exitOutdated
+			continue
+		}
+		if err := ioutil.WriteFile(file.name, generated, 0644); err != nil {
+			fmt.Fprintln(stderr, err)
+			return exitError
+		}
+	}
+	return code
+}
+
+// generateReadme replaces the generated sections of the README.
+func generateReadme(document []byte, all []model.CustomRiskRule, tags []tagReference) ([]byte, error) {
+	sections := []struct{ name, content string }{
+		{"rules", renderRules(all)},
+		{"tags", renderTags(tags)},
+	}
+	var err error
+	for _, section := range sections {
+		begin := "<!-- generated:" + section.name + " -->"
+		end := "<!-- end generated:" + section.name + " -->"
+		if document, err = replaceSection(document, begin, end, section.content); err != nil {
+			return nil, err
+		}
+	}
+	return document, nil
+}
+
+// replaceSection replaces the lines between the begin and end markers. The
+// end marker may be indented.
+func replaceSection(document []byte, begin, end, content string) ([]byte, error) {
+	start := bytes.Index(document, []byte(begin+"\n"))
+	if start < 0 {
+		return nil, fmt.Errorf("missing %q and %q markers", begin, end)
+	}
+	start += len(begin) + 1
+	stop := bytes.Index(document[start:], []byte(end))
+	if stop < 0 {
+		return nil, fmt.Errorf("missing %q and %q markers", begin, end)
+	}
+	for stop += start; stop > start && (document[stop-1] == ' ' || document[stop-1] == '\t'); {
+		stop--
+	}
+	result := append([]byte{}, document[:start]...)
+	result = append(result, content...)
+	return append(result, document[stop:]...), nil
+}
+
+func renderRules(all []model.CustomRiskRule) string {
+	var out strings.Builder
+	for _, rule := range all {
+		category := rule.Category()
+		fmt.Fprintf(&out, "### %s\n", category.Title)
+		fmt.Fprintf(&out, "`%s` | Function: %s | STRIDE: %s", category.Id, category.Function.Title(), category.STRIDE.Title())
+		if category.CWE > 0 {
+			fmt.Fprintf(&out, " | [CWE-%d](https://cwe.mitre.org/data/definitions/%d.html)", category.CWE, category.CWE)
+		}
+		fmt.Fprintf(&out, "\n\n%s\n\n", category.Description)
+		item(&out, "Detection", category.DetectionLogic)
+		item(&out, "Risk assessment", category.RiskAssessment)
+		item(&out, "False positives", category.FalsePositives)
+		item(&out, "Mitigation", category.Mitigation)
+		item(&out, "ASVS", category.ASVS)
+		if category.ModelFailurePossibleReason {
+			item(&out, "Model failure", "Findings may be caused by an incomplete model")
+		}
+		item(&out, "Tags", codeList(rule.SupportedTags()))
+	}
+	return out.String()
+}
+
+func item(out *strings.Builder, name, value string) {
+	if len(strings.TrimSpace(value)) > 0 {
+		fmt.Fprintf(out, "* **%s:** %s\n", name, strings.TrimSpace(value))
+	}
+}
+
+func codeList(values []string) string {
+	quoted := make([]string, 0, len(values))
+	for _, value := range values {
+		quoted = append(quoted, "`"+value+"`")
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// tagReference is a tag supported by one or more rules.
+type tagReference struct {
+	tag         string
+	description string
+	categories  []model.RiskCategory
+}
+
+// collectTags returns the supported tags of all rules sorted
+// case-insensitively. Every tag must be described by a rule supporting it.
+func collectTags(all []model.CustomRiskRule) ([]tagReference, error) {
+	references := make(map[string]*tagReference)
+	for _, rule := range all {
+		for _, tag := range rule.SupportedTags() {
+			key := strings.ToLower(tag)
+			reference, ok := references[key]
+			if !ok {
+				reference = &tagReference{tag: tag}
+				references[key] = reference
+			}
+			if len(reference.description) == 0 {
+				if describer, ok := rule.(rulekit.TagDescriber); ok {
+					reference.description = describer.DescribeTag(tag)
+				}
+			}
+			reference.categories = append(reference.categories, rule.Category())
+		}
+	}
+	keys := make([]string, 0, len(references))
+	for key, reference := range references {
+		if len(reference.description) == 0 {
+			return nil, fmt.Errorf("tag %s is not described by any rule supporting it", reference.tag)
+		}
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	result := make([]tagReference, 0, len(keys))
+	for _, key := range keys {
+		result = append(result, *references[key])
+	}
+	return result, nil
+}
+
+func renderTags(tags []tagReference) string {
+	var out strings.Builder
+	out.WriteString("| Tag | Description | Rules |\n|------ | ------ | ------ |\n")
+	for _, reference := range tags {
+		links := make([]string, 0, len(reference.categories))
+		for _, category := range reference.categories {
+			links = append(links, fmt.Sprintf("[%s](#%s)", category.Title, anchor(category.Title)))
+		}
+		fmt.Fprintf(&out, "| `%s` | %s | %s |\n", reference.tag, cell(reference.description), strings.Join(links, ", "))
+	}
+	for _, prefix := range []string{rulekit.AcceptTagPrefix, rulekit.DowngradeTagPrefix} {
+		tag := prefix + "<rule-id>"
+		description := rulekit.DescribeAcceptanceTag(prefix+"rule-id", "rule-id")
+		fmt.Fprintf(&out, "| `%s` | %s | All rules |\n", tag, cell(description))
+	}
+	return out.String()
+}
+
+func cell(value string) string {
+	return strings.ReplaceAll(value, "|", "\\|")
+}
+
+var anchorPunctuation = regexp.MustCompile(`[^a-z0-9 _-]`)
+
+// anchor returns the id GitHub gives the heading.
+func anchor(heading string) string {
+	return strings.ReplaceAll(anchorPunctuation.ReplaceAllString(strings.ToLower(heading), ""), " ", "-")
+}
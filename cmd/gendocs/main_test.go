@@ -0,0 +1,75 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/Otyg/threagile-rules/rules"
+)
+
+func TestFilesAreUpToDate(t *testing.T) {
+	var stderr bytes.Buffer
+	if code := run([]string{"-readme", "../../README.md", "-snippets", "../../vscode/threagile.code-snippets", "-check"}, &stderr); code != exitOk {
+		t.Errorf("exit code %d: %s", code, stderr.String())
+	}
+}
+
+func TestGenerateKeepsOtherContent(t *testing.T) {
+	document := "intro\n<!-- generated:rules -->\nstale\n<!-- end generated:rules -->\nmiddle\n<!-- generated:tags -->\n<!-- end generated:tags -->\noutro\n"
+	tags, err := collectTags(rules.All())
+	if err != nil {
+		t.Fatal(err)
+	}
+	generated, err := generateReadme([]byte(document), rules.All(), tags)
+	if err != nil {
+		t.Fatal(err)
+	}
+	result := string(generated)
+	for _, expected := range []string{"intro\n<!-- generated:rules -->\n### ", "<!-- end generated:rules -->\nmiddle\n<!-- generated:tags -->\n| Tag |", "<!-- end generated:tags -->\noutro\n", "| `non-root` |"} {
+		if !strings.Contains(result, expected) {
+			t.Errorf("expected %q in\n%s", expected, result)
+		}
+	}
+	if strings.Contains(result, "stale") {
+		t.Error("generated section was not replaced")
+	}
+}
+
+func TestGenerateRequiresMarkers(t *testing.T) {
+	if _, err := generateReadme([]byte("no markers\n"), rules.All(), nil); err == nil {
+		t.Error("expected an error for a document without markers")
+	}
+}
+
+func TestSnippetsAreValidJSON(t *testing.T) {
+	tags, err := collectTags(rules.All())
+	if err != nil {
+		t.Fatal(err)
+	}
+	document := "{\n\t// generated:tags\n\t// end generated:tags\n\t\"Hand written\": {\"prefix\": \"hand\", \"body\": [\"hand\"]}\n}\n"
+	generated, err := generateSnippets([]byte(document), rules.All(), tags)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := make([]string, 0)
+	for _, line := range strings.Split(string(generated), "\n") {
+		if !strings.HasPrefix(strings.TrimSpace(line), "//") {
+			lines = append(lines, line)
+		}
+	}
+	var snippets map[string]struct {
+		Prefix string   `json:"prefix"`
+		Body   []string `json:"body"`
+	}
+	if err := json.Unmarshal([]byte(strings.Join(lines, "\n")), &snippets); err != nil {
+		t.Fatalf("%v in\n%s", err, generated)
+	}
+	if snippet := snippets["Tag credential-lifetime:short"]; snippet.Prefix != "credential-lifetime:short" || len(snippet.Body) != 1 {
+		t.Errorf("unexpected snippet %+v", snippet)
+	}
+	if _, ok := snippets["Hand written"]; !ok {
+		t.Error("hand written snippet was lost")
+	}
+}
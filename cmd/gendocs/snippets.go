@@ -0,0 +1,59 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/Otyg/threagile-rules/internal/rulekit"
+	"github.com/threagile/threagile/model"
+)
+
+// generateSnippets replaces the generated tag snippets. They come before the
+// hand written snippets, so every generated entry ends with a comma.
+func generateSnippets(document []byte, all []model.CustomRiskRule, tags []tagReference) ([]byte, error) {
+	var out strings.Builder
+	for _, reference := range tags {
+		titles := make([]string, 0, len(reference.categories))
+		for _, category := range reference.categories {
+			titles = append(titles, category.Title)
+		}
+		writeSnippet(&out, "Tag "+reference.tag, reference.tag, snippetText(reference.tag),
+			reference.description+" ("+strings.Join(titles, ", ")+")")
+	}
+	ids := make([]string, 0, len(all))
+	for _, rule := range all {
+		ids = append(ids, rule.Category().Id)
+	}
+	choice := "${1|" + strings.Join(ids, ",") + "|}"
+	writeSnippet(&out, "Tag accept:<rule-id>", rulekit.AcceptTagPrefix, rulekit.AcceptTagPrefix+choice,
+		rulekit.DescribeAcceptanceTag(rulekit.AcceptTagPrefix+"rule-id", "rule-id"))
+	writeSnippet(&out, "Tag downgrade:<rule-id>", rulekit.DowngradeTagPrefix, rulekit.DowngradeTagPrefix+choice,
+		rulekit.DescribeAcceptanceTag(rulekit.DowngradeTagPrefix+"rule-id", "rule-id"))
+	return replaceSection(document, "\t// generated:tags", "// end generated:tags", out.String())
+}
+
+func writeSnippet(out *strings.Builder, name, prefix, body, description string) {
+	fmt.Fprintf(out, "\t%s: {\n", quote(name))
+	fmt.Fprintf(out, "\t\t\"scope\": \"yaml\",\n")
+	fmt.Fprintf(out, "\t\t\"prefix\": %s,\n", quote(prefix))
+	fmt.Fprintf(out, "\t\t\"body\": [%s],\n", quote(body))
+	fmt.Fprintf(out, "\t\t\"description\": %s\n", quote(description))
+	fmt.Fprintf(out, "\t},\n")
+}
+
+// snippetText escapes the characters that have a meaning in snippet bodies.
+func snippetText(text string) string {
+	return strings.NewReplacer(`\`, `\\`, `$`, `\$`, `}`, `\}`).Replace(text)
+}
+
+func quote(value string) string {
+	var out bytes.Buffer
+	encoder := json.NewEncoder(&out)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		panic(err)
+	}
+	return strings.TrimSuffix(out.String(), "\n")
+}
@@ -0,0 +1,185 @@
+// Command threagile-rules evaluates the risk rules of this repository against
+// a threagile model without the threagile binary.
+//
+//	threagile-rules -model threagile.yaml [-config file] [-rule id,...] [-format table|json|yaml] [-fail-on severity] [-suppressed]
+//
+// Risks of elements tagged accept:<rule-id> are left out and those tagged
+// downgrade:<rule-id> lowered, -suppressed lists them.
+//
+// The exit code is 1 when a risk still at risk has the -fail-on severity or
+// higher and 2 when the model or the arguments are invalid.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/Otyg/threagile-rules/internal/config"
+	"github.com/Otyg/threagile-rules/internal/loader"
+	"github.com/Otyg/threagile-rules/internal/rulekit"
+	"github.com/Otyg/threagile-rules/rules"
+	"github.com/threagile/threagile/model"
+)
+
+const (
+	exitOk = iota
+	exitFailOn
+	exitError
+)
+
+// ruleList collects repeated and comma separated -rule values.
+type ruleList []string
+
+func (l *ruleList) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *ruleList) Set(value string) error {
+	for _, id := range strings.Split(value, ",") {
+		if id = strings.TrimSpace(id); len(id) > 0 {
+			*l = append(*l, id)
+		}
+	}
+	return nil
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("threagile-rules", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	modelFile := flags.String("model", "threagile.yaml", "threagile model to evaluate")
+	configFile := flags.String("config", "", "rule configuration (default $"+config.EnvironmentVariable+" or "+config.FileName+" next to the model)")
+	format := flags.String("format", "table", "output format: table, json or yaml")
+	failOn := flags.String("fail-on", "", "exit with 1 when a risk of this severity or higher is found: "+strings.Join(severityNames(), ", "))
+	showSuppressed := flags.Bool("suppressed", false, "print the risks suppressed or downgraded by accept:<rule> and downgrade:<rule> tags instead")
+	var selected ruleList
+	flags.Var(&selected, "rule", "category id of a rule to run, may be repeated or comma separated (default all)")
+	if err := flags.Parse(args); err != nil {
+		return exitError
+	}
+	if flags.NArg() > 0 {
+		fmt.Fprintln(stderr, "unexpected arguments:", strings.Join(flags.Args(), " "))
+		return exitError
+	}
+	write, ok := writers[*format]
+	if !ok {
+		fmt.Fprintln(stderr, "unknown format:", *format)
+		return exitError
+	}
+	var threshold model.RiskSeverity
+	if len(*failOn) > 0 {
+		var err error
+		if threshold, err = parseSeverity(*failOn); err != nil {
+			fmt.Fprintln(stderr, err)
+			return exitError
+		}
+	}
+	selectedRules, err := selectRules(selected)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return exitError
+	}
+	if len(*configFile) == 0 {
+		*configFile = config.Locate(*modelFile)
+	}
+	if err := config.Load(*configFile); err != nil {
+		fmt.Fprintln(stderr, "unable to load configuration:", err)
+		return exitError
+	}
+	if err := loader.LoadFile(*modelFile); err != nil {
+		fmt.Fprintln(stderr, "unable to load model:", err)
+		return exitError
+	}
+	findings, suppressed := evaluate(selectedRules)
+	if *showSuppressed {
+		err = writeSuppressed[*format](stdout, suppressed)
+	} else {
+		err = write(stdout, findings, len(suppressed))
+	}
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return exitError
+	}
+	if len(*failOn) > 0 {
+		for _, risk := range findings {
+			if risk.RiskStatus.IsStillAtRisk() && risk.Severity >= threshold {
+				return exitFailOn
+			}
+		}
+	}
+	return exitOk
+}
+
+func selectRules(ids []string) ([]model.CustomRiskRule, error) {
+	if len(ids) == 0 {
+		return rules.All(), nil
+	}
+	result := make([]model.CustomRiskRule, 0)
+	for _, id := range ids {
+		rule, ok := rules.ById(id)
+		if !ok {
+			available := make([]string, 0)
+			for _, rule := range rules.All() {
+				available = append(available, rule.Category().Id)
+			}
+			return nil, fmt.Errorf("unknown rule %s, available rules: %s", id, strings.Join(available, ", "))
+		}
+		result = append(result, rule)
+	}
+	return result, nil
+}
+
+// evaluate runs the rules against model.ParsedModelRoot and applies the
+// acceptance tags. The remaining risks are sorted by their severity after
+// downgrading, highest first, and then by synthetic id. The suppressed ones
+// are sorted the same way by their severity as generated.
+func evaluate(selected []model.CustomRiskRule) ([]model.Risk, []rulekit.Suppression) {
+	generated := make([]model.Risk, 0)
+	for _, rule := range selected {
+		for _, risk := range rule.GenerateRisks() {
+			risk.CategoryId = risk.Category.Id
+			risk.RiskStatus = risk.GetRiskTrackingStatusDefaultingUnchecked()
+			generated = append(generated, risk)
+		}
+	}
+	kept, suppressed := rulekit.ApplyAcceptance(generated)
+	sort.SliceStable(kept, func(i, j int) bool {
+		return ranksBefore(kept[i], kept[j])
+	})
+	sort.SliceStable(suppressed, func(i, j int) bool {
+		return ranksBefore(suppressed[i].Risk, suppressed[j].Risk)
+	})
+	return kept, suppressed
+}
+
+func ranksBefore(a, b model.Risk) bool {
+	if a.Severity != b.Severity {
+		return a.Severity > b.Severity
+	}
+	return a.SyntheticId < b.SyntheticId
+}
+
+func parseSeverity(value string) (model.RiskSeverity, error) {
+	for _, severity := range model.RiskSeverityValues() {
+		if severity.String() == strings.ToLower(strings.TrimSpace(value)) {
+			return severity.(model.RiskSeverity), nil
+		}
+	}
+	return 0, errors.New("unknown severity: " + value + ", expected one of " + strings.Join(severityNames(), ", "))
+}
+
+func severityNames() []string {
+	names := make([]string, 0)
+	for _, severity := range model.RiskSeverityValues() {
+		names = append(names, severity.String())
+	}
+	return names
+}
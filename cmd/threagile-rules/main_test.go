@@ -0,0 +1,119 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+const fixture = "testdata/threagile.yaml"
+
+func TestRunJSON(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	code := run([]string{"-model", fixture, "-format", "json", "-rule", "missing-monitoring,missing-audit-log-of-sensitive-asset"}, &stdout, &stderr)
+	if code != exitOk {
+		t.Fatalf("exit code %d: %s", code, stderr.String())
+	}
+	var findings []finding
+	if err := json.Unmarshal(stdout.Bytes(), &findings); err != nil {
+		t.Fatal(err)
+	}
+	ids := make([]string, 0)
+	for _, finding := range findings {
+		ids = append(ids, finding.SyntheticId+":"+finding.RiskStatus)
+	}
+	expected := "missing-audit-log-of-sensitive-asset@database@access:unchecked missing-audit-log-of-sensitive-asset@database@change:unchecked " +
+		"missing-audit-log-of-sensitive-asset@web-server@access:unchecked missing-audit-log-of-sensitive-asset@web-server@change:unchecked missing-monitoring@database:mitigated"
+	if strings.Join(ids, " ") != expected {
+		t.Errorf("unexpected findings %v", ids)
+	}
+}
+
+func TestRunFailOn(t *testing.T) {
+	cases := []struct {
+		name string
+		args []string
+		code int
+	}{
+		{"below threshold", []string{"-rule", "missing-audit-log-of-sensitive-asset", "-fail-on", "critical"}, exitOk},
+		{"at threshold", []string{"-rule", "missing-audit-log-of-sensitive-asset", "-fail-on", "high"}, exitFailOn},
+		{"mitigated risks are ignored", []string{"-rule", "missing-monitoring", "-fail-on", "low"}, exitOk},
+		{"unknown rule", []string{"-rule", "no-such-rule"}, exitError},
+		{"unknown severity", []string{"-fail-on", "severe"}, exitError},
+		{"unknown format", []string{"-format", "xml"}, exitError},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			if code := run(append([]string{"-model", fixture}, c.args...), &stdout, &stderr); code != c.code {
+				t.Errorf("expected exit code %d, got %d: %s", c.code, code, stderr.String())
+			}
+		})
+	}
+}
+
+func TestRunInvalidModel(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"-model", "testdata/missing.yaml"}, &stdout, &stderr); code != exitError {
+		t.Errorf("expected exit code %d, got %d", exitError, code)
+	}
+}
+
+func TestRunSuppressed(t *testing.T) {
+	args := []string{"-model", "testdata/accepted.yaml", "-rule", "missing-audit-log-of-sensitive-asset", "-format", "json"}
+	var stdout, stderr bytes.Buffer
+	if code := run(args, &stdout, &stderr); code != exitOk {
+		t.Fatalf("exit code %d: %s", code, stderr.String())
+	}
+	var findings []finding
+	if err := json.Unmarshal(stdout.Bytes(), &findings); err != nil {
+		t.Fatal(err)
+	}
+	if len(findings) != 2 {
+		t.Fatalf("expected only the downgraded database risks, got %+v", findings)
+	}
+	for _, finding := range findings {
+		if finding.MostRelevantTechnicalAsset != "database" || finding.Severity != "elevated" {
+			t.Errorf("expected only the downgraded database risks, got %+v", findings)
+		}
+	}
+
+	stdout.Reset()
+	if code := run(append(args, "-suppressed"), &stdout, &stderr); code != exitOk {
+		t.Fatalf("exit code %d: %s", code, stderr.String())
+	}
+	var suppressed []suppressedFinding
+	if err := json.Unmarshal(stdout.Bytes(), &suppressed); err != nil {
+		t.Fatal(err)
+	}
+	actions := make([]string, 0)
+	for _, suppression := range suppressed {
+		actions = append(actions, suppression.SyntheticId+":"+suppression.Action+":"+suppression.Severity)
+	}
+	expected := "missing-audit-log-of-sensitive-asset@database@access:downgraded:high missing-audit-log-of-sensitive-asset@database@change:downgraded:high " +
+		"missing-audit-log-of-sensitive-asset@web-server@access:accepted:high missing-audit-log-of-sensitive-asset@web-server@change:accepted:high"
+	if strings.Join(actions, " ") != expected {
+		t.Errorf("unexpected suppressions %v", actions)
+	}
+}
+
+func TestRunSortsAfterDowngrading(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"-model", "testdata/accepted.yaml", "-format", "json"}, &stdout, &stderr); code != exitOk {
+		t.Fatalf("exit code %d: %s", code, stderr.String())
+	}
+	var findings []finding
+	if err := json.Unmarshal(stdout.Bytes(), &findings); err != nil {
+		t.Fatal(err)
+	}
+	ids := make([]string, 0)
+	for _, finding := range findings {
+		ids = append(ids, finding.SyntheticId+":"+finding.Severity)
+	}
+	expected := "missing-monitoring@database:high insecure-handling-of-sensitive-data@customer-data@web-server:elevated " +
+		"missing-audit-log-of-sensitive-asset@database@access:elevated missing-audit-log-of-sensitive-asset@database@change:elevated"
+	if strings.Join(ids, " ") != expected {
+		t.Errorf("unexpected findings %v", ids)
+	}
+}
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"text/tabwriter"
+
+	"github.com/Otyg/threagile-rules/internal/rulekit"
+	"github.com/threagile/threagile/model"
+	"gopkg.in/yaml.v3"
+)
+
+// finding is the JSON and YAML representation of a risk, using the field
+// names of threagile's risks.json.
+type finding struct {
+	Category                      string   `json:"category" yaml:"category"`
+	RiskStatus                    string   `json:"risk_status" yaml:"risk_status"`
+	Severity                      string   `json:"severity" yaml:"severity"`
+	ExploitationLikelihood        string   `json:"exploitation_likelihood" yaml:"exploitation_likelihood"`
+	ExploitationImpact            string   `json:"exploitation_impact" yaml:"exploitation_impact"`
+	Title                         string   `json:"title" yaml:"title"`
+	SyntheticId                   string   `json:"synthetic_id" yaml:"synthetic_id"`
+	MostRelevantDataAsset         string   `json:"most_relevant_data_asset" yaml:"most_relevant_data_asset"`
+	MostRelevantTechnicalAsset    string   `json:"most_relevant_technical_asset" yaml:"most_relevant_technical_asset"`
+	MostRelevantTrustBoundary     string   `json:"most_relevant_trust_boundary" yaml:"most_relevant_trust_boundary"`
+	MostRelevantSharedRuntime     string   `json:"most_relevant_shared_runtime" yaml:"most_relevant_shared_runtime"`
+	MostRelevantCommunicationLink string   `json:"most_relevant_communication_link" yaml:"most_relevant_communication_link"`
+	DataBreachProbability         string   `json:"data_breach_probability" yaml:"data_breach_probability"`
+	DataBreachTechnicalAssets     []string `json:"data_breach_technical_assets" yaml:"data_breach_technical_assets"`
+}
+
+// suppressedFinding is a risk left out or downgraded by an acceptance tag,
+// with the rating the rule originally gave it.
+type suppressedFinding struct {
+	Action  string `json:"action" yaml:"action"`
+	Reason  string `json:"reason" yaml:"reason"`
+	finding `yaml:",inline"`
+}
+
+var writers = map[string]func(out io.Writer, risks []model.Risk, suppressed int) error{
+	"table": writeTable,
+	"json": func(out io.Writer, risks []model.Risk, _ int) error {
+		return writeJSON(out, findings(risks))
+	},
+	"yaml": func(out io.Writer, risks []model.Risk, _ int) error {
+		return writeYAML(out, findings(risks))
+	},
+}
+
+var writeSuppressed = map[string]func(io.Writer, []rulekit.Suppression) error{
+	"table": writeSuppressedTable,
+	"json": func(out io.Writer, suppressed []rulekit.Suppression) error {
+		return writeJSON(out, suppressedFindings(suppressed))
+	},
+	"yaml": func(out io.Writer, suppressed []rulekit.Suppression) error {
+		return writeYAML(out, suppressedFindings(suppressed))
+	},
+}
+
+var markup = regexp.MustCompile(`<[^>]*>`)
+
+func findings(risks []model.Risk) []finding {
+	result := make([]finding, 0, len(risks))
+	for _, risk := range risks {
+		result = append(result, newFinding(risk))
+	}
+	return result
+}
+
+func suppressedFindings(suppressed []rulekit.Suppression) []suppressedFinding {
+	result := make([]suppressedFinding, 0, len(suppressed))
+	for _, suppression := range suppressed {
+		result = append(result, suppressedFinding{
+			Action:  suppression.Action,
+			Reason:  suppression.Reason(),
+			finding: newFinding(suppression.Risk),
+		})
+	}
+	return result
+}
+
+func newFinding(risk model.Risk) finding {
+	breachAssets := risk.DataBreachTechnicalAssetIDs
+	if breachAssets == nil {
+		breachAssets = []string{}
+	}
+	return finding{
+		Category:                      risk.CategoryId,
+		RiskStatus:                    risk.RiskStatus.String(),
+		Severity:                      risk.Severity.String(),
+		ExploitationLikelihood:        risk.ExploitationLikelihood.String(),
+		ExploitationImpact:            risk.ExploitationImpact.String(),
+		Title:                         risk.Title,
+		SyntheticId:                   risk.SyntheticId,
+		MostRelevantDataAsset:         risk.MostRelevantDataAssetId,
+		MostRelevantTechnicalAsset:    risk.MostRelevantTechnicalAssetId,
+		MostRelevantTrustBoundary:     risk.MostRelevantTrustBoundaryId,
+		MostRelevantSharedRuntime:     risk.MostRelevantSharedRuntimeId,
+		MostRelevantCommunicationLink: risk.MostRelevantCommunicationLinkId,
+		DataBreachProbability:         risk.DataBreachProbability.String(),
+		DataBreachTechnicalAssets:     breachAssets,
+	}
+}
+
+func writeTable(out io.Writer, risks []model.Risk, suppressed int) error {
+	table := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(table, "SEVERITY\tSTATUS\tSYNTHETIC ID\tTITLE")
+	for _, risk := range risks {
+		fmt.Fprintf(table, "%s\t%s\t%s\t%s\n", risk.Severity, risk.RiskStatus, risk.SyntheticId, markup.ReplaceAllString(risk.Title, ""))
+	}
+	if err := table.Flush(); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintf(out, "\n%d risks found\n", len(risks))
+	if err == nil && suppressed > 0 {
+		_, err = fmt.Fprintf(out, "%d risks accepted or downgraded by tags, see -suppressed\n", suppressed)
+	}
+	return err
+}
+
+func writeSuppressedTable(out io.Writer, suppressed []rulekit.Suppression) error {
+	table := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(table, "ACTION\tSEVERITY\tSYNTHETIC ID\tREASON")
+	for _, suppression := range suppressed {
+		fmt.Fprintf(table, "%s\t%s\t%s\t%s\n", suppression.Action, suppression.Risk.Severity, suppression.Risk.SyntheticId, suppression.Reason())
+	}
+	if err := table.Flush(); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintf(out, "\n%d risks accepted or downgraded\n", len(suppressed))
+	return err
+}
+
+func writeJSON(out io.Writer, value interface{}) error {
+	encoder := json.NewEncoder(out)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(value)
+}
+
+func writeYAML(out io.Writer, value interface{}) error {
+	encoder := yaml.NewEncoder(out)
+	defer encoder.Close()
+	return encoder.Encode(value)
+}
@@ -2,6 +2,9 @@ module github.com/Otyg/threagile-rules
 
 go 1.17
 
-require github.com/threagile/threagile v0.0.0-20201115181100-9a846523ea83
+require (
+	github.com/threagile/threagile v0.0.0-20201115181100-9a846523ea83
+	gopkg.in/yaml.v3 v3.0.0-20200506231410-2ff61e1afc86
+)
 
 require github.com/jung-kurt/gofpdf v1.9.0 // indirect
@@ -0,0 +1,169 @@
+// Package config holds the tunable settings of the rules. Every rule
+// registers its settings with the defaults it has always used; a YAML file
+// can override them per rule:
+//
+//	rules:
+//	  missing-audit-log-of-sensitive-asset:
+//	    minimum-confidentiality: confidential
+//
+// The file is taken from the THREAGILE_RULES_CONFIG environment variable or,
+// when that is not set, from threagile-rules.yaml next to the model (the
+// working directory for plugin and external process builds, which do not know
+// where the model is).
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// EnvironmentVariable names the configuration file to use.
+const EnvironmentVariable = "THREAGILE_RULES_CONFIG"
+
+// FileName is the configuration file looked for next to the model.
+const FileName = "threagile-rules.yaml"
+
+// Settings are the settings of one rule.
+type Settings interface {
+	Validate() error
+}
+
+var (
+	mutex    sync.Mutex
+	defaults = make(map[string]func() Settings)
+	current  = make(map[string]Settings)
+)
+
+// Register makes the settings of a rule configurable. newDefaults must return
+// a pointer to a fresh settings value holding the defaults.
+func Register(ruleId string, newDefaults func() Settings) {
+	mutex.Lock()
+	defer mutex.Unlock()
+	defaults[ruleId] = newDefaults
+	current[ruleId] = newDefaults()
+}
+
+// Get returns the active settings of the rule, as registered or loaded.
+func Get(ruleId string) Settings {
+	mutex.Lock()
+	defer mutex.Unlock()
+	settings, ok := current[ruleId]
+	if !ok {
+		panic("no settings registered for rule " + ruleId)
+	}
+	return settings
+}
+
+// Reset restores the defaults of all rules.
+func Reset() {
+	mutex.Lock()
+	defer mutex.Unlock()
+	for ruleId, newDefaults := range defaults {
+		current[ruleId] = newDefaults()
+	}
+}
+
+// Locate returns the configuration file to use for the model, or an empty
+// string when there is none.
+func Locate(modelFile string) string {
+	if file := os.Getenv(EnvironmentVariable); len(file) > 0 {
+		return file
+	}
+	candidate := filepath.Join(filepath.Dir(modelFile), FileName)
+	if _, err := os.Stat(candidate); err == nil {
+		return candidate
+	}
+	return ""
+}
+
+// LoadForModel loads the configuration file located for the model, or the
+// defaults when there is none.
+func LoadForModel(modelFile string) error {
+	return Load(Locate(modelFile))
+}
+
+// LoadFromEnvironment is LoadForModel for callers without a model file, it
+// looks in the working directory.
+func LoadFromEnvironment() error {
+	return LoadForModel(FileName)
+}
+
+// Load reads the configuration file. An empty file name restores the defaults.
+// Nothing changes when the file is invalid.
+func Load(file string) error {
+	if len(file) == 0 {
+		Reset()
+		return nil
+	}
+	content, err := ioutil.ReadFile(file)
+	if err != nil {
+		return err
+	}
+	if err := Parse(content); err != nil {
+		return fmt.Errorf("%s: %w", file, err)
+	}
+	return nil
+}
+
+// Parse reads the configuration. Rules missing in it get their defaults.
+func Parse(content []byte) error {
+	var document struct {
+		Rules map[string]yaml.Node `yaml:"rules"`
+	}
+	if err := strictDecode(content, &document); err != nil {
+		return err
+	}
+	mutex.Lock()
+	defer mutex.Unlock()
+	loaded := make(map[string]Settings)
+	for ruleId, newDefaults := range defaults {
+		loaded[ruleId] = newDefaults()
+	}
+	for _, ruleId := range sortedKeys(document.Rules) {
+		settings, ok := loaded[ruleId]
+		if !ok {
+			return errors.New("unknown rule: " + ruleId)
+		}
+		node := document.Rules[ruleId]
+		section, err := yaml.Marshal(&node)
+		if err != nil {
+			return err
+		}
+		if err := strictDecode(section, settings); err != nil {
+			return fmt.Errorf("rule %s: %w", ruleId, err)
+		}
+		if err := settings.Validate(); err != nil {
+			return fmt.Errorf("rule %s: %w", ruleId, err)
+		}
+	}
+	current = loaded
+	return nil
+}
+
+func strictDecode(content []byte, target interface{}) error {
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(true)
+	err := decoder.Decode(target)
+	if errors.Is(err, io.EOF) {
+		return nil
+	}
+	return err
+}
+
+func sortedKeys(nodes map[string]yaml.Node) []string {
+	keys := make([]string, 0, len(nodes))
+	for key := range nodes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
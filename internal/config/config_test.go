@@ -0,0 +1,110 @@
+package config
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/threagile/threagile/model"
+)
+
+type testSettings struct {
+	Tags                   []string        `yaml:"tags"`
+	MinimumConfidentiality Confidentiality `yaml:"minimum-confidentiality"`
+	Rating                 Rating          `yaml:"rating"`
+}
+
+func (s *testSettings) Validate() error {
+	if len(s.Tags) == 0 {
+		return errors.New("tags must not be empty")
+	}
+	return ValidateTags("tags", s.Tags)
+}
+
+func init() {
+	Register("test-rule", func() Settings {
+		return &testSettings{
+			Tags:                   []string{"pii"},
+			MinimumConfidentiality: Confidentiality{model.Restricted},
+			Rating:                 NewRating(model.Likely, model.MediumImpact),
+		}
+	})
+}
+
+func testRuleSettings() *testSettings {
+	return Get("test-rule").(*testSettings)
+}
+
+func TestParse(t *testing.T) {
+	defer Reset()
+	err := Parse([]byte(`
+rules:
+  test-rule:
+    minimum-confidentiality: confidential
+    rating:
+      impact: high
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	settings := testRuleSettings()
+	if settings.MinimumConfidentiality.Confidentiality != model.Confidential {
+		t.Errorf("minimum-confidentiality not applied: %v", settings.MinimumConfidentiality)
+	}
+	if settings.Rating.Impact.RiskExploitationImpact != model.HighImpact || settings.Rating.Likelihood.RiskExploitationLikelihood != model.Likely {
+		t.Errorf("rating not merged with the defaults: %v", settings.Rating)
+	}
+	if len(settings.Tags) != 1 || settings.Tags[0] != "pii" {
+		t.Errorf("default tags lost: %v", settings.Tags)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	defer Reset()
+	cases := map[string]struct{ content, message string }{
+		"unknown rule":    {"rules:\n  no-such-rule: {}\n", "unknown rule: no-such-rule"},
+		"unknown setting": {"rules:\n  test-rule:\n    threshold: 3\n", "field threshold not found"},
+		"unknown value":   {"rules:\n  test-rule:\n    minimum-confidentiality: secret\n", `unknown confidentiality "secret"`},
+		"invalid":         {"rules:\n  test-rule:\n    tags: []\n", "tags must not be empty"},
+		"blank tag":       {"rules:\n  test-rule:\n    tags: [' ']\n", "tags: empty tag"},
+		"unknown section": {"thresholds: {}\n", "field thresholds not found"},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := Parse([]byte(c.content))
+			if err == nil || !strings.Contains(err.Error(), c.message) {
+				t.Errorf("expected error containing %q, got %v", c.message, err)
+			}
+			if testRuleSettings().MinimumConfidentiality.Confidentiality != model.Restricted {
+				t.Error("invalid configuration must not change the settings")
+			}
+		})
+	}
+}
+
+func TestLocate(t *testing.T) {
+	directory, err := ioutil.TempDir("", "config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(directory)
+	modelFile := filepath.Join(directory, "threagile.yaml")
+	if file := Locate(modelFile); file != "" {
+		t.Errorf("expected no configuration, got %s", file)
+	}
+	nextToModel := filepath.Join(directory, FileName)
+	if err := ioutil.WriteFile(nextToModel, []byte("rules: {}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if file := Locate(modelFile); file != nextToModel {
+		t.Errorf("expected %s, got %s", nextToModel, file)
+	}
+	os.Setenv(EnvironmentVariable, "elsewhere.yaml")
+	defer os.Unsetenv(EnvironmentVariable)
+	if file := Locate(modelFile); file != "elsewhere.yaml" {
+		t.Errorf("expected the environment variable to win, got %s", file)
+	}
+}
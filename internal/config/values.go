@@ -0,0 +1,89 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/threagile/threagile/model"
+	"gopkg.in/yaml.v3"
+)
+
+// The types below let settings use threagile's enum names, e.g.
+// "restricted" or "very-likely", in the configuration file.
+
+type Confidentiality struct{ model.Confidentiality }
+
+type Criticality struct{ model.Criticality }
+
+type Likelihood struct {
+	model.RiskExploitationLikelihood
+}
+
+type Impact struct{ model.RiskExploitationImpact }
+
+func (c *Confidentiality) UnmarshalYAML(node *yaml.Node) error {
+	value, err := parseEnum(node, "confidentiality", model.ConfidentialityValues())
+	if err == nil {
+		c.Confidentiality = value.(model.Confidentiality)
+	}
+	return err
+}
+
+func (c *Criticality) UnmarshalYAML(node *yaml.Node) error {
+	value, err := parseEnum(node, "criticality", model.CriticalityValues())
+	if err == nil {
+		c.Criticality = value.(model.Criticality)
+	}
+	return err
+}
+
+func (l *Likelihood) UnmarshalYAML(node *yaml.Node) error {
+	value, err := parseEnum(node, "likelihood", model.RiskExploitationLikelihoodValues())
+	if err == nil {
+		l.RiskExploitationLikelihood = value.(model.RiskExploitationLikelihood)
+	}
+	return err
+}
+
+func (i *Impact) UnmarshalYAML(node *yaml.Node) error {
+	value, err := parseEnum(node, "impact", model.RiskExploitationImpactValues())
+	if err == nil {
+		i.RiskExploitationImpact = value.(model.RiskExploitationImpact)
+	}
+	return err
+}
+
+func parseEnum(node *yaml.Node, kind string, values []model.TypeEnum) (model.TypeEnum, error) {
+	var name string
+	if err := node.Decode(&name); err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(values))
+	for _, value := range values {
+		if value.String() == strings.TrimSpace(name) {
+			return value, nil
+		}
+		names = append(names, value.String())
+	}
+	return nil, fmt.Errorf("unknown %s %q, expected one of %s", kind, name, strings.Join(names, ", "))
+}
+
+// Rating is a likelihood and impact pair.
+type Rating struct {
+	Likelihood Likelihood `yaml:"likelihood"`
+	Impact     Impact     `yaml:"impact"`
+}
+
+// ValidateTags checks a tag list of a setting: tags must not be blank.
+func ValidateTags(setting string, tags []string) error {
+	for _, tag := range tags {
+		if len(strings.TrimSpace(tag)) == 0 {
+			return fmt.Errorf("%s: empty tag", setting)
+		}
+	}
+	return nil
+}
+
+func NewRating(likelihood model.RiskExploitationLikelihood, impact model.RiskExploitationImpact) Rating {
+	return Rating{Likelihood: Likelihood{likelihood}, Impact: Impact{impact}}
+}
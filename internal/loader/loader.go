@@ -0,0 +1,502 @@
+// Package loader parses a threagile YAML model into model.ParsedModelRoot the
+// same way the threagile binary does, so rules can be evaluated without it.
+// Only the parts of the model the risk rules look at are populated: data and
+// technical assets, communication links, trust boundaries, shared runtimes,
+// tags and (non-wildcard) risk tracking. RAA values are calculated with the
+// default threagile algorithm.
+package loader
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/threagile/threagile/model"
+	"gopkg.in/yaml.v3"
+)
+
+var validIdSyntax = regexp.MustCompile(`^[a-zA-Z0-9\-]+$`)
+
+// LoadFile reads and parses the model file, replacing model.ParsedModelRoot.
+func LoadFile(filename string) error {
+	modelYaml, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return err
+	}
+	return Parse(modelYaml)
+}
+
+// Parse parses the YAML model, replacing model.ParsedModelRoot.
+func Parse(modelYaml []byte) error {
+	var input model.ModelInput
+	if err := yaml.Unmarshal(modelYaml, &input); err != nil {
+		return err
+	}
+	return Apply(input)
+}
+
+// Apply converts an already unmarshalled model into model.ParsedModelRoot and
+// resets the global lookup maps of the model package.
+func Apply(input model.ModelInput) error {
+	model.Init()
+	businessCriticality, err := model.ParseCriticality(input.Business_criticality)
+	if err != nil {
+		return errors.New("unknown 'business_criticality' value of application: " + input.Business_criticality)
+	}
+	reportDate := time.Now()
+	if len(input.Date) > 0 {
+		reportDate, err = time.Parse("2006-01-02", input.Date)
+		if err != nil {
+			return errors.New("unable to parse 'date' value of model file")
+		}
+	}
+	model.ParsedModelRoot = model.ParsedModel{
+		Author:                   input.Author,
+		Title:                    input.Title,
+		Date:                     reportDate,
+		ManagementSummaryComment: input.Management_summary_comment,
+		BusinessCriticality:      businessCriticality,
+		BusinessOverview:         input.Business_overview,
+		TechnicalOverview:        input.Technical_overview,
+		Questions:                input.Questions,
+		AbuseCases:               input.Abuse_cases,
+		SecurityRequirements:     input.Security_requirements,
+		TagsAvailable:            lowerCaseAndTrim(input.Tags_available),
+		DataAssets:               make(map[string]model.DataAsset),
+		TechnicalAssets:          make(map[string]model.TechnicalAsset),
+		TrustBoundaries:          make(map[string]model.TrustBoundary),
+		SharedRuntimes:           make(map[string]model.SharedRuntime),
+		IndividualRiskCategories: make(map[string]model.RiskCategory),
+		RiskTracking:             make(map[string]model.RiskTracking),
+	}
+	for title, asset := range input.Data_assets {
+		if err := parseDataAsset(title, asset); err != nil {
+			return err
+		}
+	}
+	for title, asset := range input.Technical_assets {
+		if err := parseTechnicalAsset(title, asset); err != nil {
+			return err
+		}
+	}
+	for title, boundary := range input.Trust_boundaries {
+		if err := parseTrustBoundary(title, boundary); err != nil {
+			return err
+		}
+	}
+	for _, trustBoundary := range model.ParsedModelRoot.TrustBoundaries {
+		for _, nestedId := range trustBoundary.TrustBoundariesNested {
+			if _, ok := model.ParsedModelRoot.TrustBoundaries[nestedId]; !ok {
+				return errors.New("missing referenced nested trust boundary: " + nestedId)
+			}
+		}
+	}
+	for title, runtime := range input.Shared_runtimes {
+		if err := parseSharedRuntime(title, runtime); err != nil {
+			return err
+		}
+	}
+	for syntheticRiskId, tracking := range input.Risk_tracking {
+		if err := parseRiskTracking(syntheticRiskId, tracking); err != nil {
+			return err
+		}
+	}
+	for _, technicalAsset := range model.ParsedModelRoot.TechnicalAssets {
+		for _, commLink := range technicalAsset.CommunicationLinks {
+			if err := checkTechnicalAssetExists(commLink.TargetId, "communication link '"+commLink.Title+"' of technical asset '"+technicalAsset.Title+"'"); err != nil {
+				return err
+			}
+		}
+	}
+	calculateRAA()
+	return nil
+}
+
+func parseDataAsset(title string, asset model.InputDataAsset) error {
+	id := fmt.Sprintf("%v", asset.ID)
+	where := "data asset '" + title + "'"
+	usage, err := model.ParseUsage(asset.Usage)
+	if err != nil {
+		return unknownValue("usage", where, asset.Usage)
+	}
+	quantity, err := model.ParseQuantity(asset.Quantity)
+	if err != nil {
+		return unknownValue("quantity", where, asset.Quantity)
+	}
+	confidentiality, err := model.ParseConfidentiality(asset.Confidentiality)
+	if err != nil {
+		return unknownValue("confidentiality", where, asset.Confidentiality)
+	}
+	integrity, err := model.ParseCriticality(asset.Integrity)
+	if err != nil {
+		return unknownValue("integrity", where, asset.Integrity)
+	}
+	availability, err := model.ParseCriticality(asset.Availability)
+	if err != nil {
+		return unknownValue("availability", where, asset.Availability)
+	}
+	tags, err := checkTags(asset.Tags, where)
+	if err != nil {
+		return err
+	}
+	if err := checkId(id, model.ParsedModelRoot.DataAssets[id].Id); err != nil {
+		return err
+	}
+	model.ParsedModelRoot.DataAssets[id] = model.DataAsset{
+		Id:                     id,
+		Title:                  title,
+		Usage:                  usage,
+		Description:            withDefault(asset.Description, title),
+		Quantity:               quantity,
+		Tags:                   tags,
+		Origin:                 asset.Origin,
+		Owner:                  asset.Owner,
+		Confidentiality:        confidentiality,
+		Integrity:              integrity,
+		Availability:           availability,
+		JustificationCiaRating: asset.Justification_cia_rating,
+	}
+	return nil
+}
+
+func parseTechnicalAsset(title string, asset model.InputTechnicalAsset) error {
+	id := fmt.Sprintf("%v", asset.ID)
+	where := "technical asset '" + title + "'"
+	usage, err := model.ParseUsage(asset.Usage)
+	if err != nil {
+		return unknownValue("usage", where, asset.Usage)
+	}
+	assetType, err := parseEnum(model.TechnicalAssetTypeValues(), "type", where, asset.Type)
+	if err != nil {
+		return err
+	}
+	size, err := parseEnum(model.TechnicalAssetSizeValues(), "size", where, asset.Size)
+	if err != nil {
+		return err
+	}
+	technology, err := parseEnum(model.TechnicalAssetTechnologyValues(), "technology", where, asset.Technology)
+	if err != nil {
+		return err
+	}
+	encryption, err := model.ParseEncryptionStyle(asset.Encryption)
+	if err != nil {
+		return unknownValue("encryption", where, asset.Encryption)
+	}
+	machine, err := parseEnum(model.TechnicalAssetMachineValues(), "machine", where, asset.Machine)
+	if err != nil {
+		return err
+	}
+	confidentiality, err := model.ParseConfidentiality(asset.Confidentiality)
+	if err != nil {
+		return unknownValue("confidentiality", where, asset.Confidentiality)
+	}
+	integrity, err := model.ParseCriticality(asset.Integrity)
+	if err != nil {
+		return unknownValue("integrity", where, asset.Integrity)
+	}
+	availability, err := model.ParseCriticality(asset.Availability)
+	if err != nil {
+		return unknownValue("availability", where, asset.Availability)
+	}
+	dataAssetsProcessed, err := checkDataAssets(asset.Data_assets_processed, where)
+	if err != nil {
+		return err
+	}
+	dataAssetsStored, err := checkDataAssets(asset.Data_assets_stored, where)
+	if err != nil {
+		return err
+	}
+	dataFormatsAccepted := make([]model.DataFormat, 0)
+	for _, dataFormatName := range asset.Data_formats_accepted {
+		dataFormat, err := parseEnum(model.DataFormatValues(), "data_formats_accepted", where, dataFormatName)
+		if err != nil {
+			return err
+		}
+		dataFormatsAccepted = append(dataFormatsAccepted, dataFormat.(model.DataFormat))
+	}
+	tags, err := checkTags(asset.Tags, where)
+	if err != nil {
+		return err
+	}
+	communicationLinks := make([]model.CommunicationLink, 0)
+	for commLinkTitle, commLink := range asset.Communication_links {
+		parsed, err := parseCommunicationLink(id, title, commLinkTitle, commLink)
+		if err != nil {
+			return err
+		}
+		communicationLinks = append(communicationLinks, parsed)
+		model.CommunicationLinks[parsed.Id] = parsed
+		model.IncomingTechnicalCommunicationLinksMappedByTargetId[parsed.TargetId] = append(
+			model.IncomingTechnicalCommunicationLinksMappedByTargetId[parsed.TargetId], parsed)
+	}
+	if err := checkId(id, model.ParsedModelRoot.TechnicalAssets[id].Id); err != nil {
+		return err
+	}
+	model.ParsedModelRoot.TechnicalAssets[id] = model.TechnicalAsset{
+		Id:                      id,
+		Usage:                   usage,
+		Title:                   title,
+		Description:             withDefault(asset.Description, title),
+		Type:                    assetType.(model.TechnicalAssetType),
+		Size:                    size.(model.TechnicalAssetSize),
+		Technology:              technology.(model.TechnicalAssetTechnology),
+		Tags:                    tags,
+		Machine:                 machine.(model.TechnicalAssetMachine),
+		Internet:                asset.Internet,
+		Encryption:              encryption,
+		MultiTenant:             asset.Multi_tenant,
+		Redundant:               asset.Redundant,
+		CustomDevelopedParts:    asset.Custom_developed_parts,
+		UsedAsClientByHuman:     asset.Used_as_client_by_human,
+		OutOfScope:              asset.Out_of_scope,
+		JustificationOutOfScope: asset.Justification_out_of_scope,
+		Owner:                   asset.Owner,
+		Confidentiality:         confidentiality,
+		Integrity:               integrity,
+		Availability:            availability,
+		JustificationCiaRating:  asset.Justification_cia_rating,
+		DataAssetsProcessed:     dataAssetsProcessed,
+		DataAssetsStored:        dataAssetsStored,
+		DataFormatsAccepted:     dataFormatsAccepted,
+		CommunicationLinks:      communicationLinks,
+		DiagramTweakOrder:       asset.Diagram_tweak_order,
+	}
+	return nil
+}
+
+func parseCommunicationLink(sourceId, sourceTitle, title string, commLink model.InputCommunicationLink) (model.CommunicationLink, error) {
+	where := "technical asset '" + sourceTitle + "' communication link '" + title + "'"
+	authentication, err := parseEnum(model.AuthenticationValues(), "authentication", where, commLink.Authentication)
+	if err != nil {
+		return model.CommunicationLink{}, err
+	}
+	authorization, err := parseEnum(model.AuthorizationValues(), "authorization", where, commLink.Authorization)
+	if err != nil {
+		return model.CommunicationLink{}, err
+	}
+	usage, err := model.ParseUsage(commLink.Usage)
+	if err != nil {
+		return model.CommunicationLink{}, unknownValue("usage", where, commLink.Usage)
+	}
+	protocol, err := parseEnum(model.ProtocolValues(), "protocol", where, commLink.Protocol)
+	if err != nil {
+		return model.CommunicationLink{}, err
+	}
+	dataAssetsSent, err := checkDataAssets(commLink.Data_assets_sent, where)
+	if err != nil {
+		return model.CommunicationLink{}, err
+	}
+	dataAssetsReceived, err := checkDataAssets(commLink.Data_assets_received, where)
+	if err != nil {
+		return model.CommunicationLink{}, err
+	}
+	tags, err := checkTags(commLink.Tags, where)
+	if err != nil {
+		return model.CommunicationLink{}, err
+	}
+	weight := 1
+	if commLink.Diagram_tweak_weight > 0 {
+		weight = commLink.Diagram_tweak_weight
+	}
+	return model.CommunicationLink{
+		Id:                     CommunicationLinkId(sourceId, title),
+		SourceId:               sourceId,
+		TargetId:               commLink.Target,
+		Title:                  title,
+		Description:            withDefault(commLink.Description, title),
+		Protocol:               protocol.(model.Protocol),
+		Authentication:         authentication.(model.Authentication),
+		Authorization:          authorization.(model.Authorization),
+		Usage:                  usage,
+		Tags:                   tags,
+		VPN:                    commLink.VPN,
+		IpFiltered:             commLink.IP_filtered,
+		Readonly:               commLink.Readonly,
+		DataAssetsSent:         dataAssetsSent,
+		DataAssetsReceived:     dataAssetsReceived,
+		DiagramTweakWeight:     weight,
+		DiagramTweakConstraint: !commLink.Diagram_tweak_constraint,
+	}, nil
+}
+
+func parseTrustBoundary(title string, boundary model.InputTrustBoundary) error {
+	id := fmt.Sprintf("%v", boundary.ID)
+	where := "trust boundary '" + title + "'"
+	boundaryType, err := parseEnum(model.TrustBoundaryTypeValues(), "type", where, boundary.Type)
+	if err != nil {
+		return err
+	}
+	for _, assetId := range boundary.Technical_assets_inside {
+		if err := checkTechnicalAssetExists(assetId, where); err != nil {
+			return err
+		}
+		if _, exists := model.DirectContainingTrustBoundaryMappedByTechnicalAssetId[assetId]; exists {
+			return errors.New("referenced technical asset " + assetId + " at " + where + " is modeled in multiple trust boundaries")
+		}
+	}
+	tags, err := checkTags(boundary.Tags, where)
+	if err != nil {
+		return err
+	}
+	if err := checkId(id, model.ParsedModelRoot.TrustBoundaries[id].Id); err != nil {
+		return err
+	}
+	trustBoundary := model.TrustBoundary{
+		Id:                    id,
+		Title:                 title,
+		Description:           withDefault(boundary.Description, title),
+		Type:                  boundaryType.(model.TrustBoundaryType),
+		Tags:                  tags,
+		TechnicalAssetsInside: nonNil(boundary.Technical_assets_inside),
+		TrustBoundariesNested: nonNil(boundary.Trust_boundaries_nested),
+	}
+	model.ParsedModelRoot.TrustBoundaries[id] = trustBoundary
+	for _, assetId := range trustBoundary.TechnicalAssetsInside {
+		model.DirectContainingTrustBoundaryMappedByTechnicalAssetId[assetId] = trustBoundary
+	}
+	return nil
+}
+
+func parseSharedRuntime(title string, runtime model.InputSharedRuntime) error {
+	id := fmt.Sprintf("%v", runtime.ID)
+	where := "shared runtime '" + title + "'"
+	for _, assetId := range runtime.Technical_assets_running {
+		if err := checkTechnicalAssetExists(assetId, where); err != nil {
+			return err
+		}
+	}
+	tags, err := checkTags(runtime.Tags, where)
+	if err != nil {
+		return err
+	}
+	if err := checkId(id, model.ParsedModelRoot.SharedRuntimes[id].Id); err != nil {
+		return err
+	}
+	sharedRuntime := model.SharedRuntime{
+		Id:                     id,
+		Title:                  title,
+		Description:            withDefault(runtime.Description, title),
+		Tags:                   tags,
+		TechnicalAssetsRunning: nonNil(runtime.Technical_assets_running),
+	}
+	model.ParsedModelRoot.SharedRuntimes[id] = sharedRuntime
+	for _, assetId := range sharedRuntime.TechnicalAssetsRunning {
+		model.DirectContainingSharedRuntimeMappedByTechnicalAssetId[assetId] = sharedRuntime
+	}
+	return nil
+}
+
+func parseRiskTracking(syntheticRiskId string, tracking model.InputRiskTracking) error {
+	syntheticRiskId = strings.TrimSpace(syntheticRiskId)
+	where := "risk tracking '" + syntheticRiskId + "'"
+	status, err := parseEnum(model.RiskStatusValues(), "status", where, tracking.Status)
+	if err != nil {
+		return err
+	}
+	var date time.Time
+	if len(tracking.Date) > 0 {
+		date, err = time.Parse("2006-01-02", tracking.Date)
+		if err != nil {
+			return errors.New("unable to parse 'date' of " + where + ": " + tracking.Date)
+		}
+	}
+	if strings.Contains(syntheticRiskId, "*") {
+		// wildcard tracking is resolved by threagile after risk generation
+		return nil
+	}
+	model.ParsedModelRoot.RiskTracking[syntheticRiskId] = model.RiskTracking{
+		SyntheticRiskId: syntheticRiskId,
+		Justification:   tracking.Justification,
+		Ticket:          tracking.Ticket,
+		CheckedBy:       tracking.Checked_by,
+		Date:            date,
+		Status:          status.(model.RiskStatus),
+	}
+	return nil
+}
+
+// CommunicationLinkId creates the id threagile assigns to a communication
+// link: the source asset id, ">" and the normalized link title.
+func CommunicationLinkId(sourceAssetId, title string) string {
+	reg := regexp.MustCompile("[^A-Za-z0-9]+")
+	return sourceAssetId + ">" + strings.Trim(reg.ReplaceAllString(strings.ToLower(title), "-"), "- ")
+}
+
+func parseEnum(values []model.TypeEnum, field, where, value string) (model.TypeEnum, error) {
+	value = strings.TrimSpace(value)
+	for _, candidate := range values {
+		if candidate.String() == value {
+			return candidate, nil
+		}
+	}
+	return nil, unknownValue(field, where, value)
+}
+
+func unknownValue(field, where, value string) error {
+	return errors.New("unknown '" + field + "' value of " + where + ": " + value)
+}
+
+func checkId(id, existing string) error {
+	if !validIdSyntax.MatchString(id) {
+		return errors.New("invalid id syntax used (only letters, numbers, and hyphen allowed): " + id)
+	}
+	if len(existing) > 0 {
+		return errors.New("duplicate id used: " + id)
+	}
+	return nil
+}
+
+func checkTags(tags []string, where string) ([]string, error) {
+	result := make([]string, 0)
+	for _, tag := range lowerCaseAndTrim(tags) {
+		if !model.Contains(model.ParsedModelRoot.TagsAvailable, tag) {
+			return nil, errors.New("missing referenced tag in overall tag list at " + where + ": " + tag)
+		}
+		result = append(result, tag)
+	}
+	return result, nil
+}
+
+func checkDataAssets(ids []string, where string) ([]string, error) {
+	result := make([]string, 0)
+	for _, id := range ids {
+		if _, ok := model.ParsedModelRoot.DataAssets[id]; !ok {
+			return nil, errors.New("missing referenced data asset target at " + where + ": " + id)
+		}
+		result = append(result, id)
+	}
+	return result, nil
+}
+
+func checkTechnicalAssetExists(id, where string) error {
+	if _, ok := model.ParsedModelRoot.TechnicalAssets[id]; !ok {
+		return errors.New("missing referenced technical asset target at " + where + ": " + id)
+	}
+	return nil
+}
+
+func lowerCaseAndTrim(tags []string) []string {
+	result := make([]string, 0)
+	for _, tag := range tags {
+		result = append(result, strings.ToLower(strings.TrimSpace(tag)))
+	}
+	return result
+}
+
+func nonNil(values []string) []string {
+	if values == nil {
+		return make([]string, 0)
+	}
+	return values
+}
+
+func withDefault(value string, defaultWhenEmpty string) string {
+	trimmed := strings.TrimSpace(value)
+	if len(trimmed) > 0 {
+		return trimmed
+	}
+	return strings.TrimSpace(defaultWhenEmpty)
+}
@@ -0,0 +1,100 @@
+package loader
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/threagile/threagile/model"
+)
+
+const minimalModel = `
+title: Minimal
+business_criticality: important
+tags_available:
+  - Non-Root
+data_assets:
+  Customer Data:
+    id: customer-data
+    usage: business
+    quantity: many
+    confidentiality: confidential
+    integrity: critical
+    availability: operational
+technical_assets:
+  Web Server:
+    id: web-server
+    type: process
+    usage: business
+    size: application
+    technology: web-server
+    machine: container
+    encryption: none
+    tags:
+      - " non-root "
+    confidentiality: internal
+    integrity: important
+    availability: important
+    data_assets_processed:
+      - customer-data
+    communication_links:
+      "Database Access (JDBC)":
+        target: database
+        protocol: jdbc-encrypted
+        authentication: credentials
+        authorization: technical-user
+        usage: business
+        data_assets_sent:
+          - customer-data
+  Database:
+    id: database
+    type: datastore
+    usage: business
+    size: component
+    technology: database
+    machine: virtual
+    encryption: transparent
+    confidentiality: confidential
+    integrity: critical
+    availability: important
+    data_assets_stored:
+      - customer-data
+`
+
+func TestParse(t *testing.T) {
+	if err := Parse([]byte(minimalModel)); err != nil {
+		t.Fatal(err)
+	}
+	webServer := model.ParsedModelRoot.TechnicalAssets["web-server"]
+	if !webServer.IsTaggedWithAny("non-root") {
+		t.Errorf("tags not normalized: %v", webServer.Tags)
+	}
+	commLink := webServer.CommunicationLinks[0]
+	if commLink.Id != "web-server>database-access-jdbc" {
+		t.Errorf("unexpected communication link id %q", commLink.Id)
+	}
+	if len(model.IncomingTechnicalCommunicationLinksMappedByTargetId["database"]) != 1 {
+		t.Error("incoming communication link of database not mapped")
+	}
+	if webServer.RAA <= 0 || model.ParsedModelRoot.TechnicalAssets["database"].RAA != 100 {
+		t.Errorf("unexpected RAA web-server=%v database=%v", webServer.RAA, model.ParsedModelRoot.TechnicalAssets["database"].RAA)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	cases := map[string]struct{ from, to, message string }{
+		"unknown tag":         {"- \" non-root \"", "- root", "missing referenced tag"},
+		"unknown enum value":  {"technology: web-server", "technology: webserver", "unknown 'technology' value"},
+		"unknown link target": {"target: database", "target: db", "missing referenced technical asset"},
+		"unknown data asset":  {"- customer-data\n    communication_links", "- orders\n    communication_links", "missing referenced data asset"},
+		"duplicate id":        {"id: database", "id: web-server", "duplicate id"},
+		"missing criticality": {"business_criticality: important", "", "business_criticality"},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := Parse([]byte(strings.Replace(minimalModel, c.from, c.to, 1)))
+			if err == nil || !strings.Contains(err.Error(), c.message) {
+				t.Errorf("expected error containing %q, got %v", c.message, err)
+			}
+		})
+	}
+}
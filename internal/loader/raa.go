@@ -0,0 +1,95 @@
+package loader
+
+import (
+	"math"
+
+	"github.com/threagile/threagile/model"
+)
+
+// calculateRAA sets the relative attacker attractiveness (in percent) of all
+// technical assets using the algorithm of the default threagile RAA plugin.
+func calculateRAA() {
+	minimum, maximum := math.MaxFloat64, -math.MaxFloat64
+	for _, techAsset := range model.ParsedModelRoot.TechnicalAssets {
+		aa := attackerAttractiveness(techAsset)
+		minimum = math.Min(minimum, aa)
+		maximum = math.Max(maximum, aa)
+	}
+	if !(minimum < maximum) {
+		maximum = minimum + 1
+	}
+	relative := func(attractiveness float64) float64 {
+		percent := (attractiveness - minimum) / (maximum - minimum) * 100
+		if percent <= 0 {
+			percent = 1 // since 0 suggests no attacks at all
+		}
+		return percent
+	}
+	raa := make(map[string]float64)
+	for id, techAsset := range model.ParsedModelRoot.TechnicalAssets {
+		aa := attackerAttractiveness(techAsset)
+		if !techAsset.OutOfScope {
+			// pivoting: one third of the delta to the most attractive outgoing neighbour
+			adjustment := 0.0
+			for _, commLink := range techAsset.CommunicationLinks {
+				neighbour := model.ParsedModelRoot.TechnicalAssets[commLink.TargetId]
+				delta := relative(attackerAttractiveness(neighbour)) - relative(aa)
+				adjustment = math.Max(adjustment, delta/3)
+			}
+			aa += adjustment
+		}
+		raa[id] = relative(aa)
+	}
+	for id, value := range raa {
+		techAsset := model.ParsedModelRoot.TechnicalAssets[id]
+		techAsset.RAA = value
+		model.ParsedModelRoot.TechnicalAssets[id] = techAsset
+	}
+}
+
+// attackerAttractiveness sums the CIA ratings of the asset and of the data it
+// processes, stores and transfers, weighted by technology.
+func attackerAttractiveness(techAsset model.TechnicalAsset) float64 {
+	if techAsset.OutOfScope {
+		return 0
+	}
+	score := techAsset.Confidentiality.AttackerAttractivenessForAsset() +
+		techAsset.Integrity.AttackerAttractivenessForAsset() +
+		techAsset.Availability.AttackerAttractivenessForAsset()
+	for _, dataAssetId := range append(techAsset.DataAssetsProcessed, techAsset.DataAssetsStored...) {
+		dataAsset := model.ParsedModelRoot.DataAssets[dataAssetId]
+		score += dataAsset.Confidentiality.AttackerAttractivenessForProcessedOrStoredData() * dataAsset.Quantity.QuantityFactor()
+		score += dataAsset.Integrity.AttackerAttractivenessForProcessedOrStoredData() * dataAsset.Quantity.QuantityFactor()
+		score += dataAsset.Availability.AttackerAttractivenessForProcessedOrStoredData()
+	}
+	for _, commLink := range techAsset.CommunicationLinks {
+		for _, dataAssetId := range append(commLink.DataAssetsSent, commLink.DataAssetsReceived...) {
+			dataAsset := model.ParsedModelRoot.DataAssets[dataAssetId]
+			score += dataAsset.Confidentiality.AttackerAttractivenessForInOutTransferredData() * dataAsset.Quantity.QuantityFactor()
+			score += dataAsset.Integrity.AttackerAttractivenessForInOutTransferredData() * dataAsset.Quantity.QuantityFactor()
+			score += dataAsset.Availability.AttackerAttractivenessForInOutTransferredData()
+		}
+	}
+	switch techAsset.Technology {
+	case model.LoadBalancer, model.ReverseProxy:
+		score = score / 5.5
+	case model.Monitoring:
+		score = score / 5
+	case model.ContainerPlatform:
+		score = score * 5
+	case model.Vault:
+		score = score * 2
+	case model.BuildPipeline, model.SourcecodeRepository, model.ArtifactRegistry:
+		score = score * 2
+	}
+	switch {
+	case techAsset.Technology == model.IdentityProvider || techAsset.Technology == model.IdentityStoreDatabase || techAsset.Technology == model.IdentityStoreLDAP:
+		score = score * 2.5
+	case techAsset.Type == model.Datastore:
+		score = score * 2
+	}
+	if techAsset.MultiTenant {
+		score = score * 1.5
+	}
+	return score
+}
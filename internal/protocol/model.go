@@ -0,0 +1,241 @@
+package protocol
+
+import (
+	"fmt"
+
+	"github.com/Otyg/threagile-rules/internal/loader"
+	"github.com/threagile/threagile/model"
+)
+
+// Model is the parsed model threagile sends on stdin. Maps are keyed by id,
+// enums are their string values and communication links are nested in their
+// source asset, as in threagile's JSON output. Only the fields the rules use
+// are read; everything else in the document is ignored.
+type Model struct {
+	Title                    string                    `json:"title"`
+	Author                   model.Author              `json:"author"`
+	Date                     string                    `json:"date"`
+	BusinessCriticality      string                    `json:"business_criticality"`
+	ManagementSummaryComment string                    `json:"management_summary_comment"`
+	TagsAvailable            []string                  `json:"tags_available"`
+	DataAssets               map[string]DataAsset      `json:"data_assets"`
+	TechnicalAssets          map[string]TechnicalAsset `json:"technical_assets"`
+	TrustBoundaries          map[string]TrustBoundary  `json:"trust_boundaries"`
+	SharedRuntimes           map[string]SharedRuntime  `json:"shared_runtimes"`
+	RiskTracking             map[string]RiskTracking   `json:"risk_tracking"`
+}
+
+type DataAsset struct {
+	Id                     string   `json:"id"`
+	Title                  string   `json:"title"`
+	Description            string   `json:"description"`
+	Usage                  string   `json:"usage"`
+	Tags                   []string `json:"tags"`
+	Origin                 string   `json:"origin"`
+	Owner                  string   `json:"owner"`
+	Quantity               string   `json:"quantity"`
+	Confidentiality        string   `json:"confidentiality"`
+	Integrity              string   `json:"integrity"`
+	Availability           string   `json:"availability"`
+	JustificationCiaRating string   `json:"justification_cia_rating"`
+}
+
+type TechnicalAsset struct {
+	Id                      string              `json:"id"`
+	Title                   string              `json:"title"`
+	Description             string              `json:"description"`
+	Usage                   string              `json:"usage"`
+	Type                    string              `json:"type"`
+	Size                    string              `json:"size"`
+	Technology              string              `json:"technology"`
+	Machine                 string              `json:"machine"`
+	Internet                bool                `json:"internet"`
+	MultiTenant             bool                `json:"multi_tenant"`
+	Redundant               bool                `json:"redundant"`
+	CustomDevelopedParts    bool                `json:"custom_developed_parts"`
+	OutOfScope              bool                `json:"out_of_scope"`
+	UsedAsClientByHuman     bool                `json:"used_as_client_by_human"`
+	Encryption              string              `json:"encryption"`
+	JustificationOutOfScope string              `json:"justification_out_of_scope"`
+	Owner                   string              `json:"owner"`
+	Confidentiality         string              `json:"confidentiality"`
+	Integrity               string              `json:"integrity"`
+	Availability            string              `json:"availability"`
+	JustificationCiaRating  string              `json:"justification_cia_rating"`
+	Tags                    []string            `json:"tags"`
+	DataAssetsProcessed     []string            `json:"data_assets_processed"`
+	DataAssetsStored        []string            `json:"data_assets_stored"`
+	DataFormatsAccepted     []string            `json:"data_formats_accepted"`
+	CommunicationLinks      []CommunicationLink `json:"communication_links"`
+}
+
+type CommunicationLink struct {
+	Id                 string   `json:"id"`
+	SourceId           string   `json:"source_id"`
+	TargetId           string   `json:"target_id"`
+	Title              string   `json:"title"`
+	Description        string   `json:"description"`
+	Protocol           string   `json:"protocol"`
+	Tags               []string `json:"tags"`
+	VPN                bool     `json:"vpn"`
+	IpFiltered         bool     `json:"ip_filtered"`
+	Readonly           bool     `json:"readonly"`
+	Authentication     string   `json:"authentication"`
+	Authorization      string   `json:"authorization"`
+	Usage              string   `json:"usage"`
+	DataAssetsSent     []string `json:"data_assets_sent"`
+	DataAssetsReceived []string `json:"data_assets_received"`
+}
+
+type TrustBoundary struct {
+	Id                    string   `json:"id"`
+	Title                 string   `json:"title"`
+	Description           string   `json:"description"`
+	Type                  string   `json:"type"`
+	Tags                  []string `json:"tags"`
+	TechnicalAssetsInside []string `json:"technical_assets_inside"`
+	TrustBoundariesNested []string `json:"trust_boundaries_nested"`
+}
+
+type SharedRuntime struct {
+	Id                     string   `json:"id"`
+	Title                  string   `json:"title"`
+	Description            string   `json:"description"`
+	Tags                   []string `json:"tags"`
+	TechnicalAssetsRunning []string `json:"technical_assets_running"`
+}
+
+type RiskTracking struct {
+	SyntheticRiskId string `json:"synthetic_risk_id"`
+	Justification   string `json:"justification"`
+	Ticket          string `json:"ticket"`
+	CheckedBy       string `json:"checked_by"`
+	Status          string `json:"status"`
+	Date            string `json:"date"`
+}
+
+// Apply loads the model into model.ParsedModelRoot. It goes through the same
+// validation as a YAML model, so the link ids and RAA values are the ones the
+// plugin build of a rule would see.
+func (m Model) Apply() error {
+	input := model.ModelInput{
+		Title:                      m.Title,
+		Author:                     m.Author,
+		Date:                       day(m.Date),
+		Business_criticality:       m.BusinessCriticality,
+		Management_summary_comment: m.ManagementSummaryComment,
+		Tags_available:             m.TagsAvailable,
+		Data_assets:                make(map[string]model.InputDataAsset),
+		Technical_assets:           make(map[string]model.InputTechnicalAsset),
+		Trust_boundaries:           make(map[string]model.InputTrustBoundary),
+		Shared_runtimes:            make(map[string]model.InputSharedRuntime),
+		Risk_tracking:              make(map[string]model.InputRiskTracking),
+	}
+	for id, asset := range m.DataAssets {
+		input.Data_assets[titleOrId(asset.Title, id)] = model.InputDataAsset{
+			ID:                       id,
+			Description:              asset.Description,
+			Usage:                    asset.Usage,
+			Tags:                     asset.Tags,
+			Origin:                   asset.Origin,
+			Owner:                    asset.Owner,
+			Quantity:                 asset.Quantity,
+			Confidentiality:          asset.Confidentiality,
+			Integrity:                asset.Integrity,
+			Availability:             asset.Availability,
+			Justification_cia_rating: asset.JustificationCiaRating,
+		}
+	}
+	for id, asset := range m.TechnicalAssets {
+		links := make(map[string]model.InputCommunicationLink)
+		for _, commLink := range asset.CommunicationLinks {
+			if _, exists := links[commLink.Title]; exists {
+				return fmt.Errorf("duplicate communication link title of technical asset %s: %s", id, commLink.Title)
+			}
+			links[commLink.Title] = model.InputCommunicationLink{
+				Target:               commLink.TargetId,
+				Description:          commLink.Description,
+				Protocol:             commLink.Protocol,
+				Authentication:       commLink.Authentication,
+				Authorization:        commLink.Authorization,
+				Tags:                 commLink.Tags,
+				VPN:                  commLink.VPN,
+				IP_filtered:          commLink.IpFiltered,
+				Readonly:             commLink.Readonly,
+				Usage:                commLink.Usage,
+				Data_assets_sent:     commLink.DataAssetsSent,
+				Data_assets_received: commLink.DataAssetsReceived,
+			}
+		}
+		input.Technical_assets[titleOrId(asset.Title, id)] = model.InputTechnicalAsset{
+			ID:                         id,
+			Description:                asset.Description,
+			Type:                       asset.Type,
+			Usage:                      asset.Usage,
+			Used_as_client_by_human:    asset.UsedAsClientByHuman,
+			Out_of_scope:               asset.OutOfScope,
+			Justification_out_of_scope: asset.JustificationOutOfScope,
+			Size:                       asset.Size,
+			Technology:                 asset.Technology,
+			Tags:                       asset.Tags,
+			Internet:                   asset.Internet,
+			Machine:                    asset.Machine,
+			Encryption:                 asset.Encryption,
+			Owner:                      asset.Owner,
+			Confidentiality:            asset.Confidentiality,
+			Integrity:                  asset.Integrity,
+			Availability:               asset.Availability,
+			Justification_cia_rating:   asset.JustificationCiaRating,
+			Multi_tenant:               asset.MultiTenant,
+			Redundant:                  asset.Redundant,
+			Custom_developed_parts:     asset.CustomDevelopedParts,
+			Data_assets_processed:      asset.DataAssetsProcessed,
+			Data_assets_stored:         asset.DataAssetsStored,
+			Data_formats_accepted:      asset.DataFormatsAccepted,
+			Communication_links:        links,
+		}
+	}
+	for id, boundary := range m.TrustBoundaries {
+		input.Trust_boundaries[titleOrId(boundary.Title, id)] = model.InputTrustBoundary{
+			ID:                      id,
+			Description:             boundary.Description,
+			Type:                    boundary.Type,
+			Tags:                    boundary.Tags,
+			Technical_assets_inside: boundary.TechnicalAssetsInside,
+			Trust_boundaries_nested: boundary.TrustBoundariesNested,
+		}
+	}
+	for id, runtime := range m.SharedRuntimes {
+		input.Shared_runtimes[titleOrId(runtime.Title, id)] = model.InputSharedRuntime{
+			ID:                       id,
+			Description:              runtime.Description,
+			Tags:                     runtime.Tags,
+			Technical_assets_running: runtime.TechnicalAssetsRunning,
+		}
+	}
+	for syntheticRiskId, tracking := range m.RiskTracking {
+		input.Risk_tracking[syntheticRiskId] = model.InputRiskTracking{
+			Status:        tracking.Status,
+			Justification: tracking.Justification,
+			Ticket:        tracking.Ticket,
+			Date:          day(tracking.Date),
+			Checked_by:    tracking.CheckedBy,
+		}
+	}
+	return loader.Apply(input)
+}
+
+func titleOrId(title, id string) string {
+	if len(title) > 0 {
+		return title
+	}
+	return id
+}
+
+// day cuts timestamps down to the date, threagile serializes dates with time.
+func day(date string) string {
+	if len(date) > len("2006-01-02") {
+		return date[:len("2006-01-02")]
+	}
+	return date
+}
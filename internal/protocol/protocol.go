@@ -0,0 +1,113 @@
+// Package protocol lets a rule run as a standalone executable, the way newer
+// threagile releases invoke custom risk rules as external processes:
+//
+//	rule -get-info          prints the category and supported tags as JSON
+//	rule -generate-risks    reads the parsed model as JSON on stdin and
+//	                        prints the generated risks as JSON
+//
+// Errors are written to stderr with a non-zero exit code.
+package protocol
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/threagile/threagile/model"
+)
+
+// Info is the -get-info answer.
+type Info struct {
+	Id       string   `json:"id"`
+	Category Category `json:"category"`
+	Tags     []string `json:"tags"`
+}
+
+// Category is model.RiskCategory with threagile's JSON field names.
+type Category struct {
+	Id                         string             `json:"id"`
+	Title                      string             `json:"title"`
+	Description                string             `json:"description"`
+	Impact                     string             `json:"impact"`
+	ASVS                       string             `json:"asvs"`
+	CheatSheet                 string             `json:"cheat_sheet"`
+	Action                     string             `json:"action"`
+	Mitigation                 string             `json:"mitigation"`
+	Check                      string             `json:"check"`
+	Function                   model.RiskFunction `json:"function"`
+	STRIDE                     model.STRIDE       `json:"stride"`
+	DetectionLogic             string             `json:"detection_logic"`
+	RiskAssessment             string             `json:"risk_assessment"`
+	FalsePositives             string             `json:"false_positives"`
+	ModelFailurePossibleReason bool               `json:"model_failure_possible_reason"`
+	CWE                        int                `json:"cwe"`
+}
+
+func NewInfo(rule model.CustomRiskRule) Info {
+	category := rule.Category()
+	return Info{
+		Id: category.Id,
+		Category: Category{
+			Id:                         category.Id,
+			Title:                      category.Title,
+			Description:                category.Description,
+			Impact:                     category.Impact,
+			ASVS:                       category.ASVS,
+			CheatSheet:                 category.CheatSheet,
+			Action:                     category.Action,
+			Mitigation:                 category.Mitigation,
+			Check:                      category.Check,
+			Function:                   category.Function,
+			STRIDE:                     category.STRIDE,
+			DetectionLogic:             category.DetectionLogic,
+			RiskAssessment:             category.RiskAssessment,
+			FalsePositives:             category.FalsePositives,
+			ModelFailurePossibleReason: category.ModelFailurePossibleReason,
+			CWE:                        category.CWE,
+		},
+		Tags: rule.SupportedTags(),
+	}
+}
+
+// GenerateRisks loads the model read from in and writes the risks of the rule
+// to out. CategoryId and RiskStatus are filled in so the output matches the
+// risks threagile writes in risks.json.
+func GenerateRisks(rule model.CustomRiskRule, in io.Reader, out io.Writer) error {
+	var parsed Model
+	if err := json.NewDecoder(in).Decode(&parsed); err != nil {
+		return fmt.Errorf("unable to read model: %w", err)
+	}
+	if err := parsed.Apply(); err != nil {
+		return err
+	}
+	risks := rule.GenerateRisks()
+	for i := range risks {
+		risks[i].CategoryId = risks[i].Category.Id
+		risks[i].RiskStatus = risks[i].GetRiskTrackingStatusDefaultingUnchecked()
+	}
+	return json.NewEncoder(out).Encode(risks)
+}
+
+// Serve implements the external process protocol for the rule and exits.
+func Serve(rule model.CustomRiskRule) {
+	getInfo := flag.Bool("get-info", false, "print the risk category and supported tags")
+	generateRisks := flag.Bool("generate-risks", false, "read the model from stdin and print the generated risks")
+	flag.Parse()
+	var err error
+	switch {
+	case *getInfo:
+		err = json.NewEncoder(os.Stdout).Encode(NewInfo(rule))
+	case *generateRisks:
+		err = GenerateRisks(rule, os.Stdin, os.Stdout)
+	default:
+		flag.Usage()
+		err = errors.New("either -get-info or -generate-risks is required")
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,78 @@
+package protocol
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/Otyg/threagile-rules/internal/rulekit"
+	"github.com/threagile/threagile/model"
+)
+
+type linkRule string
+
+func (r linkRule) Category() model.RiskCategory {
+	return model.RiskCategory{Id: "test-rule", Function: model.Operations, STRIDE: model.Tampering}
+}
+
+func (r linkRule) SupportedTags() []string {
+	return []string{"non-root"}
+}
+
+func (r linkRule) GenerateRisks() []model.Risk {
+	risks := make([]model.Risk, 0)
+	for _, commLink := range model.CommunicationLinks {
+		risks = append(risks, rulekit.NewRisk(r.Category(), commLink.Title).
+			CommunicationLink(commLink.Id).
+			IdentifiedBy(commLink.Id).
+			Build())
+	}
+	return risks
+}
+
+func TestNewInfo(t *testing.T) {
+	encoded, err := json.Marshal(NewInfo(linkRule("")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var info map[string]interface{}
+	if err := json.Unmarshal(encoded, &info); err != nil {
+		t.Fatal(err)
+	}
+	category := info["category"].(map[string]interface{})
+	if info["id"] != "test-rule" || category["function"] != "operations" || category["stride"] != "tampering" {
+		t.Errorf("unexpected info %s", encoded)
+	}
+}
+
+func TestGenerateRisks(t *testing.T) {
+	in, err := os.Open("testdata/model.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer in.Close()
+	var out bytes.Buffer
+	if err := GenerateRisks(linkRule(""), in, &out); err != nil {
+		t.Fatal(err)
+	}
+	var risks []map[string]interface{}
+	if err := json.Unmarshal(out.Bytes(), &risks); err != nil {
+		t.Fatal(err)
+	}
+	if len(risks) != 1 {
+		t.Fatalf("expected one risk, got %s", out.String())
+	}
+	risk := risks[0]
+	if risk["category"] != "test-rule" || risk["synthetic_id"] != "test-rule@web-server>database-access" || risk["risk_status"] != "mitigated" {
+		t.Errorf("unexpected risk %s", out.String())
+	}
+}
+
+func TestGenerateRisksInvalidModel(t *testing.T) {
+	var out bytes.Buffer
+	err := GenerateRisks(linkRule(""), bytes.NewBufferString(`{"business_criticality": "unknown"}`), &out)
+	if err == nil {
+		t.Error("expected an error for an invalid model")
+	}
+}
@@ -0,0 +1,109 @@
+package rulekit
+
+import (
+	"github.com/threagile/threagile/model"
+)
+
+// Acceptance tags let model authors handle a rule's findings on the element
+// itself instead of in risk_tracking, which depends on stable synthetic ids:
+//
+//	accept:<rule-id>     the finding is suppressed
+//	downgrade:<rule-id>  likelihood and impact are lowered one step
+//
+// The tags are honoured on the technical asset, communication link and data
+// asset a risk refers to.
+const (
+	AcceptTagPrefix    = "accept:"
+	DowngradeTagPrefix = "downgrade:"
+)
+
+const (
+	Accepted   = "accepted"
+	Downgraded = "downgraded"
+)
+
+// Suppression records a risk that was accepted or downgraded by a tag.
+type Suppression struct {
+	Risk        model.Risk // as generated by the rule
+	Action      string     // Accepted or Downgraded
+	Tag         string
+	ElementKind string // "technical asset", "communication link" or "data asset"
+	ElementId   string
+}
+
+func (s Suppression) Reason() string {
+	return s.ElementKind + " " + s.ElementId + " is tagged " + s.Tag
+}
+
+// AcceptanceTagPrefixes returns the prefixes of the acceptance tags, which
+// take the id of any rule, threagile's built-in ones included.
+func AcceptanceTagPrefixes() []string {
+	return []string{AcceptTagPrefix, DowngradeTagPrefix}
+}
+
+// AcceptanceTags returns the acceptance tags of the rule category.
+func AcceptanceTags(categoryId string) []string {
+	return []string{AcceptTagPrefix + categoryId, DowngradeTagPrefix + categoryId}
+}
+
+// ApplyAcceptance drops accepted risks and lowers downgraded ones. It returns
+// the remaining risks and what was suppressed, in the order of the input.
+func ApplyAcceptance(risks []model.Risk) ([]model.Risk, []Suppression) {
+	kept := make([]model.Risk, 0, len(risks))
+	suppressed := make([]Suppression, 0)
+	for _, risk := range risks {
+		if suppression, ok := taggedElement(risk, AcceptTagPrefix+risk.Category.Id); ok {
+			suppression.Action = Accepted
+			suppressed = append(suppressed, suppression)
+			continue
+		}
+		if suppression, ok := taggedElement(risk, DowngradeTagPrefix+risk.Category.Id); ok {
+			suppression.Action = Downgraded
+			suppressed = append(suppressed, suppression)
+			risk.ExploitationLikelihood = LowerLikelihood(risk.ExploitationLikelihood)
+			risk.ExploitationImpact = LowerImpact(risk.ExploitationImpact)
+			risk.Severity = model.CalculateSeverity(risk.ExploitationLikelihood, risk.ExploitationImpact)
+		}
+		kept = append(kept, risk)
+	}
+	return kept, suppressed
+}
+
+func taggedElement(risk model.Risk, tag string) (Suppression, bool) {
+	if technicalAsset, ok := model.ParsedModelRoot.TechnicalAssets[risk.MostRelevantTechnicalAssetId]; ok && technicalAsset.IsTaggedWithAny(tag) {
+		return Suppression{Risk: risk, Tag: tag, ElementKind: "technical asset", ElementId: technicalAsset.Id}, true
+	}
+	if commLink, ok := model.CommunicationLinks[risk.MostRelevantCommunicationLinkId]; ok && commLink.IsTaggedWithAny(tag) {
+		return Suppression{Risk: risk, Tag: tag, ElementKind: "communication link", ElementId: commLink.Id}, true
+	}
+	if dataAsset, ok := model.ParsedModelRoot.DataAssets[risk.MostRelevantDataAssetId]; ok && dataAsset.IsTaggedWithAny(tag) {
+		return Suppression{Risk: risk, Tag: tag, ElementKind: "data asset", ElementId: dataAsset.Id}, true
+	}
+	return Suppression{}, false
+}
+
+// AcceptingRule applies the acceptance tags to the risks of the wrapped rule
+// and adds them to its supported tags. The plugin and standalone builds use
+// it, the threagile-rules command applies the tags itself to report them.
+type AcceptingRule struct {
+	model.CustomRiskRule
+}
+
+func (r AcceptingRule) SupportedTags() []string {
+	return append(append([]string{}, r.CustomRiskRule.SupportedTags()...), AcceptanceTags(r.Category().Id)...)
+}
+
+func (r AcceptingRule) GenerateRisks() []model.Risk {
+	kept, _ := ApplyAcceptance(r.CustomRiskRule.GenerateRisks())
+	return kept
+}
+
+func (r AcceptingRule) DescribeTag(tag string) string {
+	if description := DescribeAcceptanceTag(tag, r.Category().Id); len(description) > 0 {
+		return description
+	}
+	if describer, ok := r.CustomRiskRule.(TagDescriber); ok {
+		return describer.DescribeTag(tag)
+	}
+	return ""
+}
@@ -0,0 +1,66 @@
+package rulekit
+
+import (
+	"testing"
+
+	"github.com/threagile/threagile/model"
+)
+
+func TestApplyAcceptance(t *testing.T) {
+	model.ParsedModelRoot = model.ParsedModel{
+		TechnicalAssets: map[string]model.TechnicalAsset{
+			"server": {Id: "server", Tags: []string{"accept:some-rule"}},
+			"client": {Id: "client"},
+		},
+		DataAssets: map[string]model.DataAsset{
+			"secret": {Id: "secret", Tags: []string{"downgrade:some-rule"}},
+		},
+	}
+	model.CommunicationLinks = map[string]model.CommunicationLink{
+		"client>call": {Id: "client>call", Tags: []string{"accept:other-rule"}},
+	}
+	risk := func(categoryId, syntheticId, technicalAsset, commLink, dataAsset string) model.Risk {
+		return model.Risk{
+			Category:                        model.RiskCategory{Id: categoryId},
+			SyntheticId:                     syntheticId,
+			Severity:                        model.HighSeverity,
+			ExploitationLikelihood:          model.VeryLikely,
+			ExploitationImpact:              model.HighImpact,
+			MostRelevantTechnicalAssetId:    technicalAsset,
+			MostRelevantCommunicationLinkId: commLink,
+			MostRelevantDataAssetId:         dataAsset,
+		}
+	}
+	kept, suppressed := ApplyAcceptance([]model.Risk{
+		risk("some-rule", "accepted-by-asset", "server", "", "secret"),
+		risk("some-rule", "downgraded-by-data", "client", "", "secret"),
+		risk("other-rule", "accepted-by-link", "client", "client>call", ""),
+		risk("other-rule", "untagged", "server", "", "secret"),
+	})
+
+	if len(kept) != 2 || kept[0].SyntheticId != "downgraded-by-data" || kept[1].SyntheticId != "untagged" {
+		t.Fatalf("unexpected risks kept: %+v", kept)
+	}
+	if kept[0].ExploitationLikelihood != model.Likely || kept[0].ExploitationImpact != model.MediumImpact || kept[0].Severity != model.ElevatedSeverity {
+		t.Errorf("risk not downgraded: %+v", kept[0])
+	}
+	if kept[1].Severity != model.HighSeverity {
+		t.Errorf("untagged risk changed: %+v", kept[1])
+	}
+	expected := []string{
+		"accepted: technical asset server is tagged accept:some-rule",
+		"downgraded: data asset secret is tagged downgrade:some-rule",
+		"accepted: communication link client>call is tagged accept:other-rule",
+	}
+	if len(suppressed) != len(expected) {
+		t.Fatalf("expected %d suppressions, got %d", len(expected), len(suppressed))
+	}
+	for i, suppression := range suppressed {
+		if actual := suppression.Action + ": " + suppression.Reason(); actual != expected[i] {
+			t.Errorf("expected %q, got %q", expected[i], actual)
+		}
+	}
+	if suppressed[1].Risk.Severity != model.HighSeverity {
+		t.Errorf("suppression should keep the original rating, got %v", suppressed[1].Risk.Severity)
+	}
+}
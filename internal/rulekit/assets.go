@@ -0,0 +1,49 @@
+package rulekit
+
+import (
+	"sort"
+
+	"github.com/threagile/threagile/model"
+)
+
+// MonitoringLinks returns the outgoing communication links of the asset that
+// target a monitoring asset, sorted by title.
+func MonitoringLinks(technicalAsset model.TechnicalAsset) []model.CommunicationLink {
+	result := make([]model.CommunicationLink, 0)
+	for _, commLink := range technicalAsset.CommunicationLinksSorted() {
+		destination := model.ParsedModelRoot.TechnicalAssets[commLink.TargetId]
+		if destination.Technology == model.Monitoring {
+			result = append(result, commLink)
+		}
+	}
+	return result
+}
+
+// IncomingLinks returns the communication links targeting the asset, sorted
+// by source id and title.
+func IncomingLinks(technicalAsset model.TechnicalAsset) []model.CommunicationLink {
+	result := append([]model.CommunicationLink{}, model.IncomingTechnicalCommunicationLinksMappedByTargetId[technicalAsset.Id]...)
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].SourceId != result[j].SourceId {
+			return result[i].SourceId < result[j].SourceId
+		}
+		return result[i].Title < result[j].Title
+	})
+	return result
+}
+
+func SendsToMonitoring(technicalAsset model.TechnicalAsset) bool {
+	return len(MonitoringLinks(technicalAsset)) > 0
+}
+
+// DataAssetsProcessedOrStored returns the processed data assets followed by
+// the stored ones not also processed, each part sorted by title.
+func DataAssetsProcessedOrStored(technicalAsset model.TechnicalAsset) []model.DataAsset {
+	result := technicalAsset.DataAssetsProcessedSorted()
+	for _, data := range technicalAsset.DataAssetsStoredSorted() {
+		if !model.Contains(technicalAsset.DataAssetsProcessed, data.Id) {
+			result = append(result, data)
+		}
+	}
+	return result
+}
@@ -0,0 +1,162 @@
+package rulekit
+
+import (
+	"sort"
+	"strings"
+)
+
+// Algorithm tags name the cryptography an element uses, e.g.
+// crypto:aes-256-gcm or hash:sha-256.
+const (
+	CryptoTagPrefix = "crypto:"
+	HashTagPrefix   = "hash:"
+)
+
+// Strength grades an algorithm. The order is from best to worst, so the
+// weakest of several algorithms is the highest.
+type Strength int
+
+const (
+	StrongAlgorithm  Strength = iota
+	UnknownAlgorithm          // tagged with an algorithm missing in the catalogue
+	WeakAlgorithm             // deprecated or with known weaknesses
+	BrokenAlgorithm           // practically attackable
+)
+
+func (s Strength) String() string {
+	return [...]string{"strong", "unknown", "weak", "broken"}[s]
+}
+
+// Algorithm is an entry of the algorithm catalogue.
+type Algorithm struct {
+	Tag      string
+	Strength Strength
+	// QuantumVulnerable asymmetric algorithms can be broken by a large enough
+	// quantum computer.
+	QuantumVulnerable bool
+	Description       string
+}
+
+// Describe is the description of the algorithm followed by its strength,
+// unless the description already ends with it.
+func (a Algorithm) Describe() string {
+	if strings.HasSuffix(a.Description, ", "+a.Strength.String()) {
+		return a.Description
+	}
+	return a.Description + ", " + a.Strength.String()
+}
+
+// Name is the tag without its crypto: or hash: prefix.
+func (a Algorithm) Name() string {
+	return strings.TrimPrefix(strings.TrimPrefix(a.Tag, CryptoTagPrefix), HashTagPrefix)
+}
+
+var algorithms = []Algorithm{
+	{"crypto:aes-128-gcm", StrongAlgorithm, false, "AES-128 in GCM mode"},
+	{"crypto:aes-256-gcm", StrongAlgorithm, false, "AES-256 in GCM mode"},
+	{"crypto:aes-256-xts", StrongAlgorithm, false, "AES-256 in XTS mode, as used for disk encryption"},
+	{"crypto:chacha20-poly1305", StrongAlgorithm, false, "ChaCha20-Poly1305"},
+	{"crypto:aes-128-cbc", WeakAlgorithm, false, "AES-128 in CBC mode, unauthenticated"},
+	{"crypto:aes-256-cbc", WeakAlgorithm, false, "AES-256 in CBC mode, unauthenticated"},
+	{"crypto:aes-ecb", BrokenAlgorithm, false, "AES in ECB mode, leaks patterns of the plaintext"},
+	{"crypto:blowfish", WeakAlgorithm, false, "Blowfish, 64-bit block size"},
+	{"crypto:3des", WeakAlgorithm, false, "Triple DES, deprecated"},
+	{"crypto:des", BrokenAlgorithm, false, "DES, 56-bit key"},
+	{"crypto:rc4", BrokenAlgorithm, false, "RC4, biased keystream"},
+	{"crypto:null", BrokenAlgorithm, false, "no encryption at all"},
+	{"crypto:export", BrokenAlgorithm, false, "export grade ciphers with 40 or 56 bit keys"},
+	{"crypto:rsa-4096", StrongAlgorithm, true, "RSA with a 4096 bit key"},
+	{"crypto:rsa-3072", StrongAlgorithm, true, "RSA with a 3072 bit key"},
+	{"crypto:rsa-2048", StrongAlgorithm, true, "RSA with a 2048 bit key"},
+	{"crypto:rsa-1024", WeakAlgorithm, true, "RSA with a 1024 bit key"},
+	{"crypto:rsa-512", BrokenAlgorithm, true, "RSA with a 512 bit key"},
+	{"crypto:rsa-key-exchange", WeakAlgorithm, true, "RSA key exchange, without forward secrecy"},
+	{"crypto:ecdh-p256", StrongAlgorithm, true, "ECDH on the P-256 curve"},
+	{"crypto:ecdh-p384", StrongAlgorithm, true, "ECDH on the P-384 curve"},
+	{"crypto:x25519", StrongAlgorithm, true, "ECDH on Curve25519"},
+	{"crypto:ecdsa-p256", StrongAlgorithm, true, "ECDSA on the P-256 curve"},
+	{"crypto:ecdsa-p384", StrongAlgorithm, true, "ECDSA on the P-384 curve"},
+	{"crypto:ed25519", StrongAlgorithm, true, "EdDSA on Curve25519"},
+	{"crypto:ml-kem-768", StrongAlgorithm, false, "ML-KEM-768, post-quantum key encapsulation"},
+	{"crypto:ml-dsa-65", StrongAlgorithm, false, "ML-DSA-65, post-quantum signatures"},
+	{"hash:sha-256", StrongAlgorithm, false, "SHA-256"},
+	{"hash:sha-384", StrongAlgorithm, false, "SHA-384"},
+	{"hash:sha-512", StrongAlgorithm, false, "SHA-512"},
+	{"hash:sha3-256", StrongAlgorithm, false, "SHA3-256"},
+	{"hash:argon2id", StrongAlgorithm, false, "Argon2id password hashing"},
+	{"hash:bcrypt", StrongAlgorithm, false, "bcrypt password hashing"},
+	{"hash:scrypt", StrongAlgorithm, false, "scrypt password hashing"},
+	{"hash:pbkdf2", StrongAlgorithm, false, "PBKDF2 password hashing"},
+	{"hash:sha-1", WeakAlgorithm, false, "SHA-1, collisions are practical"},
+	{"hash:md5", BrokenAlgorithm, false, "MD5, broken"},
+}
+
+// AlgorithmTags returns the tags of the algorithm catalogue.
+func AlgorithmTags() []string {
+	tags := make([]string, 0, len(algorithms))
+	for _, algorithm := range algorithms {
+		tags = append(tags, algorithm.Tag)
+	}
+	return tags
+}
+
+// DescribeAlgorithmTag returns the catalogue description of the tag, or an
+// empty string.
+func DescribeAlgorithmTag(tag string) string {
+	if algorithm, ok := LookupAlgorithm(tag); ok {
+		return "The element uses " + algorithm.Describe()
+	}
+	return ""
+}
+
+// TaggedAlgorithms returns the algorithms named by crypto: and hash: tags,
+// sorted by tag. Tags missing in the catalogue are UnknownAlgorithm.
+func TaggedAlgorithms(tags ...[]string) []Algorithm {
+	found := make(map[string]Algorithm)
+	for _, list := range tags {
+		for _, tag := range list {
+			tag = strings.ToLower(strings.TrimSpace(tag))
+			if !strings.HasPrefix(tag, CryptoTagPrefix) && !strings.HasPrefix(tag, HashTagPrefix) {
+				continue
+			}
+			algorithm, ok := LookupAlgorithm(tag)
+			if !ok {
+				algorithm = Algorithm{Tag: tag, Strength: UnknownAlgorithm, Description: "an algorithm missing in the catalogue"}
+			}
+			found[tag] = algorithm
+		}
+	}
+	result := make([]Algorithm, 0, len(found))
+	for _, algorithm := range found {
+		result = append(result, algorithm)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Tag < result[j].Tag
+	})
+	return result
+}
+
+// Weakest returns the weakest of the algorithms, the first one on a tie.
+func Weakest(algorithms []Algorithm) (Algorithm, bool) {
+	if len(algorithms) == 0 {
+		return Algorithm{}, false
+	}
+	weakest := algorithms[0]
+	for _, algorithm := range algorithms[1:] {
+		if algorithm.Strength > weakest.Strength {
+			weakest = algorithm
+		}
+	}
+	return weakest, true
+}
+
+// LookupAlgorithm returns the catalogue entry of the tag.
+func LookupAlgorithm(tag string) (Algorithm, bool) {
+	tag = strings.ToLower(tag)
+	for _, algorithm := range algorithms {
+		if algorithm.Tag == tag {
+			return algorithm, true
+		}
+	}
+	return Algorithm{}, false
+}
@@ -0,0 +1,25 @@
+package rulekit
+
+import "testing"
+
+func TestWeakestTaggedAlgorithm(t *testing.T) {
+	cases := []struct {
+		tags     []string
+		expected string
+		strength Strength
+	}{
+		{[]string{"crypto:aes-256-gcm"}, "crypto:aes-256-gcm", StrongAlgorithm},
+		{[]string{"Crypto:AES-256-GCM", "hash:md5"}, "hash:md5", BrokenAlgorithm},
+		{[]string{"crypto:aes-256-gcm", "crypto:camellia"}, "crypto:camellia", UnknownAlgorithm},
+		{[]string{"crypto:camellia", "crypto:3des", "pii"}, "crypto:3des", WeakAlgorithm},
+	}
+	for _, c := range cases {
+		algorithm, ok := Weakest(TaggedAlgorithms(c.tags))
+		if !ok || algorithm.Tag != c.expected || algorithm.Strength != c.strength {
+			t.Errorf("%v: expected %s (%v), got %+v", c.tags, c.expected, c.strength, algorithm)
+		}
+	}
+	if _, ok := Weakest(TaggedAlgorithms([]string{"pii"}, nil)); ok {
+		t.Error("expected no algorithm without crypto: or hash: tags")
+	}
+}
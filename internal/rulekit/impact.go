@@ -0,0 +1,88 @@
+package rulekit
+
+import (
+	"github.com/threagile/threagile/model"
+)
+
+// ImpactFromConfidentiality maps a confidentiality rating to the exploitation
+// impact used throughout the rules: public and internal data is low impact,
+// restricted medium, confidential high and strictly confidential very high.
+func ImpactFromConfidentiality(confidentiality model.Confidentiality) model.RiskExploitationImpact {
+	switch confidentiality {
+	case model.Restricted:
+		return model.MediumImpact
+	case model.Confidential:
+		return model.HighImpact
+	case model.StrictlyConfidential:
+		return model.VeryHighImpact
+	default:
+		return model.LowImpact
+	}
+}
+
+// ImpactFromCriticality is the integrity/availability counterpart of
+// ImpactFromConfidentiality.
+func ImpactFromCriticality(criticality model.Criticality) model.RiskExploitationImpact {
+	switch criticality {
+	case model.Important:
+		return model.MediumImpact
+	case model.Critical:
+		return model.HighImpact
+	case model.MissionCritical:
+		return model.VeryHighImpact
+	default:
+		return model.LowImpact
+	}
+}
+
+func MaxImpact(impacts ...model.RiskExploitationImpact) model.RiskExploitationImpact {
+	highest := model.LowImpact
+	for _, impact := range impacts {
+		if impact > highest {
+			highest = impact
+		}
+	}
+	return highest
+}
+
+// LowerLikelihood lowers the likelihood one step, never below Unlikely.
+func LowerLikelihood(likelihood model.RiskExploitationLikelihood) model.RiskExploitationLikelihood {
+	if likelihood > model.Unlikely {
+		return likelihood - 1
+	}
+	return likelihood
+}
+
+// RaiseLikelihood raises the likelihood one step, never above Frequent.
+func RaiseLikelihood(likelihood model.RiskExploitationLikelihood) model.RiskExploitationLikelihood {
+	if likelihood < model.Frequent {
+		return likelihood + 1
+	}
+	return likelihood
+}
+
+// LowerImpact lowers the impact one step, never below LowImpact.
+func LowerImpact(impact model.RiskExploitationImpact) model.RiskExploitationImpact {
+	if impact > model.LowImpact {
+		return impact - 1
+	}
+	return impact
+}
+
+// LowerBreachProbability lowers the probability one step, never below
+// Improbable.
+func LowerBreachProbability(probability model.DataBreachProbability) model.DataBreachProbability {
+	if probability > model.Improbable {
+		return probability - 1
+	}
+	return probability
+}
+
+// RaiseBreachProbability raises the probability one step, never above
+// Probable.
+func RaiseBreachProbability(probability model.DataBreachProbability) model.DataBreachProbability {
+	if probability < model.Probable {
+		return probability + 1
+	}
+	return probability
+}
@@ -0,0 +1,120 @@
+// Package rulekit holds the building blocks shared by the custom risk rules in
+// this repository: a risk builder, CIA to impact mappings and the synthetic id
+// conventions used for risk tracking.
+package rulekit
+
+import (
+	"strings"
+
+	"github.com/threagile/threagile/model"
+)
+
+// RiskBuilder assembles a model.Risk. Severity and SyntheticId are derived
+// when Build is called so they always match the rating and references.
+type RiskBuilder struct {
+	risk     model.Risk
+	idParts  []string
+	explicit bool
+}
+
+// NewRisk starts a risk of the given category rated as Unlikely/Low.
+func NewRisk(category model.RiskCategory, title string) *RiskBuilder {
+	return &RiskBuilder{
+		risk: model.Risk{
+			Category:                    category,
+			Title:                       title,
+			ExploitationLikelihood:      model.Unlikely,
+			ExploitationImpact:          model.LowImpact,
+			DataBreachProbability:       model.Improbable,
+			DataBreachTechnicalAssetIDs: []string{},
+		},
+	}
+}
+
+func (b *RiskBuilder) Rating(likelihood model.RiskExploitationLikelihood, impact model.RiskExploitationImpact) *RiskBuilder {
+	b.risk.ExploitationLikelihood = likelihood
+	b.risk.ExploitationImpact = impact
+	return b
+}
+
+func (b *RiskBuilder) TechnicalAsset(id string) *RiskBuilder {
+	b.risk.MostRelevantTechnicalAssetId = id
+	return b
+}
+
+func (b *RiskBuilder) DataAsset(id string) *RiskBuilder {
+	b.risk.MostRelevantDataAssetId = id
+	return b
+}
+
+func (b *RiskBuilder) CommunicationLink(id string) *RiskBuilder {
+	b.risk.MostRelevantCommunicationLinkId = id
+	return b
+}
+
+func (b *RiskBuilder) TrustBoundary(id string) *RiskBuilder {
+	b.risk.MostRelevantTrustBoundaryId = id
+	return b
+}
+
+func (b *RiskBuilder) SharedRuntime(id string) *RiskBuilder {
+	b.risk.MostRelevantSharedRuntimeId = id
+	return b
+}
+
+// DataBreach sets the breach probability and the technical assets where the
+// breach would happen.
+func (b *RiskBuilder) DataBreach(probability model.DataBreachProbability, technicalAssetIds ...string) *RiskBuilder {
+	b.risk.DataBreachProbability = probability
+	b.risk.DataBreachTechnicalAssetIDs = append([]string{}, technicalAssetIds...)
+	return b
+}
+
+// IdentifiedBy overrides the default synthetic id with category@part@part...
+// Rules use it to keep ids stable that existing risk tracking refers to.
+func (b *RiskBuilder) IdentifiedBy(parts ...string) *RiskBuilder {
+	b.idParts = parts
+	b.explicit = true
+	return b
+}
+
+func (b *RiskBuilder) Build() model.Risk {
+	risk := b.risk
+	risk.Severity = model.CalculateSeverity(risk.ExploitationLikelihood, risk.ExploitationImpact)
+	if b.explicit {
+		risk.SyntheticId = SyntheticId(risk.Category.Id, b.idParts...)
+	} else {
+		risk.SyntheticId = DefaultSyntheticId(risk)
+	}
+	return risk
+}
+
+// SyntheticId joins the category id and the given parts with "@", skipping
+// empty parts.
+func SyntheticId(categoryId string, parts ...string) string {
+	result := []string{categoryId}
+	for _, part := range parts {
+		if len(part) > 0 {
+			result = append(result, part)
+		}
+	}
+	return strings.Join(result, "@")
+}
+
+// DefaultSyntheticId follows the order threagile itself uses for individual
+// risks: technical asset, communication link, trust boundary, shared runtime
+// and data asset.
+func DefaultSyntheticId(risk model.Risk) string {
+	return SyntheticId(risk.Category.Id,
+		risk.MostRelevantTechnicalAssetId,
+		risk.MostRelevantCommunicationLinkId,
+		risk.MostRelevantTrustBoundaryId,
+		risk.MostRelevantSharedRuntimeId,
+		risk.MostRelevantDataAssetId)
+}
+
+// TitleAt renders the "<b>label</b> risk at <b>asset</b>" title used by most
+// rules.
+func TitleAt(label string, technicalAsset model.TechnicalAsset) string {
+	return "<b>" + label + "</b> risk at <b>" + technicalAsset.Title + "</b>"
+}
@@ -0,0 +1,47 @@
+package rulekit
+
+import "strings"
+
+// TagDescriber is implemented by rules that document their supported tags.
+// The descriptions end up in the README tag reference.
+type TagDescriber interface {
+	DescribeTag(tag string) string
+}
+
+// OpenTagPrefixer is implemented by rules with tags that take any value after
+// a prefix, e.g. crypto:<algorithm>, whose supported tags only list known
+// values. Other values of such tags are not misspellings.
+type OpenTagPrefixer interface {
+	OpenTagPrefixes() []string
+}
+
+// sharedTags describes the tags several rules use to mark sensitive data.
+var sharedTags = map[string]string{
+	"pii":        "Personal Identifiable Information",
+	"credential": "Credential, or similar such as encryption key",
+}
+
+// DescribeTag looks the tag up in the rule's own descriptions and then in the
+// tags shared between rules. Tags are compared case-insensitively, the way
+// threagile matches them. It returns an empty string for unknown tags.
+func DescribeTag(tag string, own map[string]string) string {
+	tag = strings.ToLower(tag)
+	for known, description := range own {
+		if strings.ToLower(known) == tag {
+			return description
+		}
+	}
+	return sharedTags[tag]
+}
+
+// DescribeAcceptanceTag describes the accept:<rule-id> and
+// downgrade:<rule-id> tags of the category, or returns an empty string.
+func DescribeAcceptanceTag(tag, categoryId string) string {
+	switch strings.ToLower(tag) {
+	case AcceptTagPrefix + categoryId:
+		return "Findings of this rule on the tagged element are accepted and not reported"
+	case DowngradeTagPrefix + categoryId:
+		return "Findings of this rule on the tagged element get their likelihood and impact lowered one step"
+	}
+	return ""
+}
@@ -0,0 +1,177 @@
+// Package ruletest runs custom risk rules against YAML model fixtures and
+// compares the generated risks with golden JSON files.
+//
+// Each rule directory keeps its fixtures in testdata/<name>.yaml next to the
+// expected output in testdata/<name>.golden.json. After an intended change of
+// a rule's output, regenerate the golden files with
+//
+//	go test ./rules/... -update
+//
+// and review the diff. A fixture can be run with other rule settings by placing
+// them in testdata/<name>.config.yaml, see package config. To run the same
+// model with several settings, place each of them in
+// testdata/<name>.<variant>.config.yaml; the variant is compared with
+// testdata/<name>.<variant>.golden.json.
+package ruletest
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Otyg/threagile-rules/internal/config"
+	"github.com/Otyg/threagile-rules/internal/loader"
+	"github.com/threagile/threagile/model"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files with the generated risks")
+
+// Rule is the part of model.CustomRiskRule the harness needs.
+type Rule interface {
+	Category() model.RiskCategory
+	GenerateRisks() []model.Risk
+}
+
+// Finding is the golden file representation of a risk. The category is
+// reduced to its id, the rest of the category is covered by the rule itself.
+type Finding struct {
+	Category                      string   `json:"category"`
+	SyntheticId                   string   `json:"synthetic_id"`
+	Title                         string   `json:"title"`
+	Severity                      string   `json:"severity"`
+	ExploitationLikelihood        string   `json:"exploitation_likelihood"`
+	ExploitationImpact            string   `json:"exploitation_impact"`
+	DataBreachProbability         string   `json:"data_breach_probability"`
+	DataBreachTechnicalAssets     []string `json:"data_breach_technical_assets"`
+	MostRelevantDataAsset         string   `json:"most_relevant_data_asset,omitempty"`
+	MostRelevantTechnicalAsset    string   `json:"most_relevant_technical_asset,omitempty"`
+	MostRelevantCommunicationLink string   `json:"most_relevant_communication_link,omitempty"`
+	MostRelevantTrustBoundary     string   `json:"most_relevant_trust_boundary,omitempty"`
+	MostRelevantSharedRuntime     string   `json:"most_relevant_shared_runtime,omitempty"`
+}
+
+// Findings converts risks into their golden file representation, in the
+// order the rule emitted them: rules must produce a deterministic order, which
+// the golden files check along with the risks themselves.
+func Findings(risks []model.Risk) []Finding {
+	result := make([]Finding, 0, len(risks))
+	for _, risk := range risks {
+		result = append(result, Finding{
+			Category:                      risk.Category.Id,
+			SyntheticId:                   risk.SyntheticId,
+			Title:                         risk.Title,
+			Severity:                      risk.Severity.String(),
+			ExploitationLikelihood:        risk.ExploitationLikelihood.String(),
+			ExploitationImpact:            risk.ExploitationImpact.String(),
+			DataBreachProbability:         risk.DataBreachProbability.String(),
+			DataBreachTechnicalAssets:     nonNil(risk.DataBreachTechnicalAssetIDs),
+			MostRelevantDataAsset:         risk.MostRelevantDataAssetId,
+			MostRelevantTechnicalAsset:    risk.MostRelevantTechnicalAssetId,
+			MostRelevantCommunicationLink: risk.MostRelevantCommunicationLinkId,
+			MostRelevantTrustBoundary:     risk.MostRelevantTrustBoundaryId,
+			MostRelevantSharedRuntime:     risk.MostRelevantSharedRuntimeId,
+		})
+	}
+	return result
+}
+
+// RunFixtures runs the rule against every testdata/*.yaml fixture of the
+// calling package, and every config variant of them, one subtest each.
+func RunFixtures(t *testing.T, rule Rule) {
+	t.Helper()
+	fixtures, err := filepath.Glob(filepath.Join("testdata", "*.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	configs, _ := filepath.Glob(filepath.Join("testdata", "*.config.yaml"))
+	fixtures = without(fixtures, configs)
+	if len(fixtures) == 0 {
+		t.Fatal("no fixtures found in testdata")
+	}
+	for _, fixture := range fixtures {
+		fixture := fixture
+		name := strings.TrimSuffix(filepath.Base(fixture), ".yaml")
+		t.Run(name, func(t *testing.T) {
+			RunFixture(t, rule, fixture, strings.TrimSuffix(fixture, ".yaml")+".golden.json")
+		})
+	}
+	for _, configFile := range configs {
+		configFile := configFile
+		variant := strings.TrimSuffix(configFile, ".config.yaml")
+		if model.Contains(fixtures, variant+".yaml") {
+			continue // the own settings of a fixture, see RunFixture
+		}
+		fixture := variant[:strings.LastIndex(variant, ".")+1] + "yaml"
+		if !strings.Contains(filepath.Base(variant), ".") || !model.Contains(fixtures, fixture) {
+			t.Errorf("%s matches neither a fixture nor a variant of one", configFile)
+			continue
+		}
+		t.Run(filepath.Base(variant), func(t *testing.T) {
+			run(t, rule, fixture, configFile, variant+".golden.json")
+		})
+	}
+}
+
+// RunFixture loads the model fixture, with its own settings if there are any,
+// generates the risks of the rule and compares them with the golden file.
+func RunFixture(t *testing.T, rule Rule, fixture, golden string) {
+	t.Helper()
+	configFile := strings.TrimSuffix(fixture, ".yaml") + ".config.yaml"
+	if _, err := os.Stat(configFile); err != nil {
+		configFile = ""
+	}
+	run(t, rule, fixture, configFile, golden)
+}
+
+func run(t *testing.T, rule Rule, fixture, configFile, golden string) {
+	t.Helper()
+	if err := config.Load(configFile); err != nil {
+		t.Fatalf("loading %s: %v", configFile, err)
+	}
+	if err := loader.LoadFile(fixture); err != nil {
+		t.Fatalf("loading %s: %v", fixture, err)
+	}
+	var buffer bytes.Buffer
+	encoder := json.NewEncoder(&buffer)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(Findings(rule.GenerateRisks())); err != nil {
+		t.Fatal(err)
+	}
+	actual := buffer.Bytes()
+	if *update {
+		if err := ioutil.WriteFile(golden, actual, 0644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	expected, err := ioutil.ReadFile(golden)
+	if err != nil {
+		t.Fatalf("reading golden file (run with -update to create it): %v", err)
+	}
+	if !bytes.Equal(expected, actual) {
+		t.Errorf("risks differ from %s (run with -update to accept)\nexpected:\n%s\nactual:\n%s", golden, expected, actual)
+	}
+}
+
+func without(values, excluded []string) []string {
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		if !model.Contains(excluded, value) {
+			result = append(result, value)
+		}
+	}
+	return result
+}
+
+func nonNil(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"github.com/Otyg/threagile-rules/internal/config"
+	"github.com/Otyg/threagile-rules/internal/protocol"
+	"github.com/Otyg/threagile-rules/internal/rulekit"
+	// registers the settings of all rules, so the whole configuration file
+	// can be validated
+	_ "github.com/Otyg/threagile-rules/rules"
+	"github.com/Otyg/threagile-rules/rules/monitoringprotection"
+)
+
+// CustomRiskRule is the symbol threagile looks up in the plugin build.
+var CustomRiskRule = rulekit.AcceptingRule{CustomRiskRule: monitoringprotection.Rule("")}
+
+// init loads the rule configuration, see package config. An invalid
+// configuration stops threagile rather than silently using the defaults.
+func init() {
+	if err := config.LoadFromEnvironment(); err != nil {
+		panic("invalid threagile-rules configuration: " + err.Error())
+	}
+}
+
+// main runs the rule as an external process, see package protocol. When built
+// with -buildmode=plugin threagile loads CustomRiskRule instead.
+func main() {
+	protocol.Serve(CustomRiskRule)
+}
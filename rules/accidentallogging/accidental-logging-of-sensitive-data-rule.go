@@ -0,0 +1,175 @@
+// Package accidentallogging implements the accidental-logging-of-sensitive-data risk rule.
+package accidentallogging
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/Otyg/threagile-rules/internal/rulekit"
+	"github.com/threagile/threagile/model"
+)
+
+type Rule string
+
+func (r Rule) Category() model.RiskCategory {
+	return model.RiskCategory{
+		Id:                         "accidental-logging-of-sensitive-data",
+		Title:                      "Logging of Sensitive Data",
+		Description:                "When storing or processing sensitive data there is a risk that the data is written to logfiles.",
+		Impact:                     "Bypassing access controls to the sensitive data",
+		ASVS:                       "v4.0.2-7.1 - Log Content",
+		CheatSheet:                 "https://cheatsheetseries.owasp.org/cheatsheets/Logging_Cheat_Sheet.html#data-to-exclude",
+		Action:                     "Logging and monitoring",
+		Mitigation:                 "Review log statements and ensure that sensitive data, such as personal indenfiable information and credentials, is not logged without a legit reason.",
+		Check:                      "Are recommendations from the linked cheat sheet and referenced ASVS chapter applied?",
+		Function:                   model.Development,
+		STRIDE:                     model.InformationDisclosure,
+		DetectionLogic:             "Entities processing, or storing, data with confidentiality class restricted or higher which sends data to a monitoring target.",
+		RiskAssessment:             "One risk per source and monitoring target. The impact depends on the sensitivity of the data assets sent to the monitoring target, with a minimum impact for sources that only process or store sensitive data. Each redaction tag on the source or the links lowers the likelihood one step and an allowlist tag drops the risk, unless the links send credentials.",
+		FalsePositives:             "None, either the risk is mitigated or accepted",
+		ModelFailurePossibleReason: false,
+		CWE:                        532,
+	}
+}
+
+func (r Rule) SupportedTags() []string {
+	settings := settings()
+	tags := make([]string, 0)
+	for _, list := range [][]string{settings.Tags, settings.CredentialTags, settings.RedactionTags, settings.AllowlistTags} {
+		for _, tag := range list {
+			if !model.ContainsCaseInsensitiveAny(tags, tag) {
+				tags = append(tags, tag)
+			}
+		}
+	}
+	return tags
+}
+
+var tagDescriptions = map[string]string{
+	"log-redaction":                "Sensitive values are masked before the logs are shipped",
+	"log-tokenization":             "Sensitive values are replaced by tokens before the logs are shipped",
+	"structured-logging-allowlist": "Only allowlisted fields of structured log events are shipped",
+}
+
+func (r Rule) DescribeTag(tag string) string {
+	return rulekit.DescribeTag(tag, tagDescriptions)
+}
+
+func (r Rule) GenerateRisks() []model.Risk {
+	risks := make([]model.Risk, 0)
+	settings := settings()
+	for _, id := range model.SortedTechnicalAssetIDs() {
+		technicalAsset := model.ParsedModelRoot.TechnicalAssets[id]
+		if !IsSensitiveSource(technicalAsset) {
+			continue
+		}
+		linksByTarget := make(map[string][]model.CommunicationLink)
+		targets := make([]string, 0)
+		for _, commLink := range rulekit.MonitoringLinks(technicalAsset) {
+			if _, seen := linksByTarget[commLink.TargetId]; !seen {
+				targets = append(targets, commLink.TargetId)
+			}
+			linksByTarget[commLink.TargetId] = append(linksByTarget[commLink.TargetId], commLink)
+		}
+		sort.Strings(targets)
+		for _, targetId := range targets {
+			if risk, ok := r.createRisk(technicalAsset, model.ParsedModelRoot.TechnicalAssets[targetId], linksByTarget[targetId], settings); ok {
+				risks = append(risks, risk)
+			}
+		}
+	}
+	return risks
+}
+
+// IsSensitiveSource reports whether logging from the technical asset is
+// checked by the rule: it is in scope, not a monitoring asset itself and
+// processes or stores sensitive data.
+func IsSensitiveSource(technicalAsset model.TechnicalAsset) bool {
+	if technicalAsset.OutOfScope || technicalAsset.Technology == model.Monitoring {
+		return false
+	}
+	return len(SensitiveDataAssets(rulekit.DataAssetsProcessedOrStored(technicalAsset))) > 0
+}
+
+// SensitiveDataAssets returns the data assets the rule treats as sensitive,
+// in the given order.
+func SensitiveDataAssets(dataAssets []model.DataAsset) []model.DataAsset {
+	settings := settings()
+	result := make([]model.DataAsset, 0)
+	for _, data := range dataAssets {
+		if isSensitive(data, settings) {
+			result = append(result, data)
+		}
+	}
+	return result
+}
+
+func isSensitive(data model.DataAsset, settings *Settings) bool {
+	return data.Confidentiality >= settings.MinimumConfidentiality.Confidentiality || data.IsTaggedWithAny(settings.Tags...)
+}
+
+// createRisk rates the logging from the source to one monitoring target by
+// the sensitive data assets sent on the links between them. Sources that only
+// process or store sensitive data get the minimum impact. It returns false
+// when the logging is allowlisted.
+func (r Rule) createRisk(source model.TechnicalAsset, target model.TechnicalAsset, commLinks []model.CommunicationLink, settings *Settings) (model.Risk, bool) {
+	impact := settings.MinimumImpact.RiskExploitationImpact
+	likelihood := settings.Likelihood.RiskExploitationLikelihood
+	var mostRelevantData model.DataAsset
+	mostRelevantLink := commLinks[0]
+	sendsCredentials := false
+	mitigations := make([]string, 0)
+	for _, tag := range source.Tags {
+		mitigations = appendMitigation(mitigations, tag, settings)
+	}
+	for _, commLink := range commLinks {
+		for _, tag := range commLink.Tags {
+			mitigations = appendMitigation(mitigations, tag, settings)
+		}
+		for _, data := range commLink.DataAssetsSentSorted() {
+			if data.IsTaggedWithAny(settings.CredentialTags...) {
+				sendsCredentials = true
+			}
+			if !isSensitive(data, settings) {
+				continue
+			}
+			impact = rulekit.MaxImpact(impact, rulekit.ImpactFromConfidentiality(data.Confidentiality))
+			if len(mostRelevantData.Id) == 0 || data.Confidentiality > mostRelevantData.Confidentiality {
+				mostRelevantData, mostRelevantLink = data, commLink
+			}
+		}
+	}
+	title := rulekit.TitleAt("Logging of Sensitive Data", source) + " to <b>" + target.Title + "</b>"
+	if sendsCredentials {
+		if len(mitigations) > 0 {
+			title += ", credentials are sent despite " + strings.Join(mitigations, ", ")
+		}
+	} else if len(mitigations) > 0 {
+		for _, mitigation := range mitigations {
+			if model.ContainsCaseInsensitiveAny(settings.AllowlistTags, mitigation) {
+				return model.Risk{}, false
+			}
+			likelihood = rulekit.LowerLikelihood(likelihood)
+		}
+		title += ", mitigated by " + strings.Join(mitigations, ", ")
+	}
+	return rulekit.NewRisk(r.Category(), title).
+		Rating(likelihood, impact).
+		TechnicalAsset(source.Id).
+		CommunicationLink(mostRelevantLink.Id).
+		DataAsset(mostRelevantData.Id).
+		DataBreach(model.Possible, source.Id, target.Id).
+		IdentifiedBy(source.Id, target.Id).
+		Build(), true
+}
+
+// appendMitigation adds the tag when it is a redaction or allowlist tag that
+// is not in the list yet.
+func appendMitigation(mitigations []string, tag string, settings *Settings) []string {
+	if model.ContainsCaseInsensitiveAny(settings.RedactionTags, tag) || model.ContainsCaseInsensitiveAny(settings.AllowlistTags, tag) {
+		if !model.ContainsCaseInsensitiveAny(mitigations, tag) {
+			return append(mitigations, tag)
+		}
+	}
+	return mitigations
+}
@@ -0,0 +1,52 @@
+package accidentallogging
+
+import (
+	"github.com/Otyg/threagile-rules/internal/config"
+	"github.com/threagile/threagile/model"
+)
+
+// Settings of the rule. Data assets are sensitive when their confidentiality
+// is at least MinimumConfidentiality or they are tagged with any of Tags.
+//
+// Each of RedactionTags on the source asset or a link to the monitoring
+// target lowers the likelihood one step, AllowlistTags drop the finding.
+// Neither applies when a link sends data assets tagged with any of
+// CredentialTags, those are logged on purpose.
+type Settings struct {
+	Tags                   []string               `yaml:"tags"`
+	MinimumConfidentiality config.Confidentiality `yaml:"minimum-confidentiality"`
+	Likelihood             config.Likelihood      `yaml:"likelihood"`
+	MinimumImpact          config.Impact          `yaml:"minimum-impact"`
+	RedactionTags          []string               `yaml:"redaction-tags"`
+	AllowlistTags          []string               `yaml:"allowlist-tags"`
+	CredentialTags         []string               `yaml:"credential-tags"`
+}
+
+func defaultSettings() config.Settings {
+	return &Settings{
+		Tags:                   []string{"PII", "credential"},
+		MinimumConfidentiality: config.Confidentiality{Confidentiality: model.Restricted},
+		Likelihood:             config.Likelihood{RiskExploitationLikelihood: model.Likely},
+		MinimumImpact:          config.Impact{RiskExploitationImpact: model.MediumImpact},
+		RedactionTags:          []string{"log-redaction", "log-tokenization"},
+		AllowlistTags:          []string{"structured-logging-allowlist"},
+		CredentialTags:         []string{"credential"},
+	}
+}
+
+func (s *Settings) Validate() error {
+	for setting, tags := range map[string][]string{"tags": s.Tags, "redaction-tags": s.RedactionTags, "allowlist-tags": s.AllowlistTags, "credential-tags": s.CredentialTags} {
+		if err := config.ValidateTags(setting, tags); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func settings() *Settings {
+	return config.Get(Rule("").Category().Id).(*Settings)
+}
+
+func init() {
+	config.Register(Rule("").Category().Id, defaultSettings)
+}
@@ -0,0 +1,139 @@
+// Package alertingpath implements the missing-alerting-path risk rule.
+package alertingpath
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/Otyg/threagile-rules/internal/rulekit"
+	"github.com/Otyg/threagile-rules/rules/missingmonitoring"
+	"github.com/threagile/threagile/model"
+)
+
+type Rule string
+
+func (r Rule) Category() model.RiskCategory {
+	return model.RiskCategory{
+		Id:                         "missing-alerting-path",
+		Title:                      "Missing Alerting Path",
+		Description:                "A monitoring platform only helps incident response when somebody is notified of what it detects. Platforms without a link to a mail server, a chat or incident tool or an on-call system collect events nobody acts upon in time.",
+		Impact:                     "Attacks on the most critical assets are detected late, or only when the logs are read for another reason.",
+		ASVS:                       "v4.0.2-7.2 - Log Processing Requirements",
+		CheatSheet:                 "https://cheatsheetseries.owasp.org/cheatsheets/Logging_Cheat_Sheet.html",
+		Action:                     "Logging and monitoring",
+		Mitigation:                 "Route alerts of the monitoring platform to a notification channel that reaches the people on call.",
+		Check:                      "Do alerts on the most critical assets reach somebody who responds to them?",
+		Function:                   model.Operations,
+		STRIDE:                     model.Repudiation,
+		DetectionLogic:             "In-scope technical assets with strictly confidential or mission-critical ratings, including the data they process or store, which send to monitoring assets, directly or through the forwarders and log collectors accepted by missing-monitoring, none of which have an outgoing link to a mail server, over a mail protocol, or to an asset or over a link tagged as alerting target. Links to untagged external entities, such as a chat or incident tool, do not count.",
+		RiskAssessment:             "The impact depends on the ratings of the monitored asset, the likelihood is the configured one.",
+		FalsePositives:             "Platforms notifying by means not modelled as communication links, e.g. a built-in pager integration, or through external chat or incident tools whose link or asset is not tagged as alerting target.",
+		ModelFailurePossibleReason: true,
+		CWE:                        778,
+	}
+}
+
+func (r Rule) SupportedTags() []string {
+	return settings().AlertingTags
+}
+
+var tagDescriptions = map[string]string{
+	"alerting": "The asset or link notifies people of alerts raised by monitoring assets",
+	"on-call":  "The asset pages the people on call",
+}
+
+func (r Rule) DescribeTag(tag string) string {
+	return rulekit.DescribeTag(tag, tagDescriptions)
+}
+
+func (r Rule) GenerateRisks() []model.Risk {
+	risks := make([]model.Risk, 0)
+	settings := settings()
+	for _, id := range model.SortedTechnicalAssetIDs() {
+		technicalAsset := model.ParsedModelRoot.TechnicalAssets[id]
+		if technicalAsset.OutOfScope || technicalAsset.Technology == model.Monitoring || !isCritical(technicalAsset, settings) {
+			continue
+		}
+		platforms, via := monitoringPlatforms(technicalAsset)
+		if len(platforms) == 0 {
+			continue // reported by missing-monitoring
+		}
+		alerting := false
+		for _, platform := range platforms {
+			if hasAlertingPath(platform, settings) {
+				alerting = true
+				break
+			}
+		}
+		if !alerting {
+			risks = append(risks, r.createRisk(technicalAsset, platforms, via, settings))
+		}
+	}
+	return risks
+}
+
+func isCritical(technicalAsset model.TechnicalAsset, settings *Settings) bool {
+	return technicalAsset.HighestConfidentiality() >= settings.MinimumConfidentiality.Confidentiality ||
+		technicalAsset.HighestIntegrity() >= settings.MinimumCriticality.Criticality ||
+		technicalAsset.HighestAvailability() >= settings.MinimumCriticality.Criticality
+}
+
+// monitoringPlatforms returns the monitoring assets the asset sends to,
+// sorted by id. Without a direct link it returns the platform its logs reach
+// indirectly, the way missing-monitoring considers the asset monitored, and
+// the path to it.
+func monitoringPlatforms(technicalAsset model.TechnicalAsset) ([]model.TechnicalAsset, string) {
+	if !rulekit.SendsToMonitoring(technicalAsset) {
+		if platform, via, ok := missingmonitoring.IndirectMonitoring(technicalAsset); ok {
+			return []model.TechnicalAsset{platform}, via
+		}
+		return []model.TechnicalAsset{}, ""
+	}
+	ids := make([]string, 0)
+	for _, commLink := range rulekit.MonitoringLinks(technicalAsset) {
+		if !model.Contains(ids, commLink.TargetId) {
+			ids = append(ids, commLink.TargetId)
+		}
+	}
+	sort.Strings(ids)
+	result := make([]model.TechnicalAsset, 0, len(ids))
+	for _, id := range ids {
+		result = append(result, model.ParsedModelRoot.TechnicalAssets[id])
+	}
+	return result, ""
+}
+
+// hasAlertingPath reports whether the monitoring asset has an outgoing link
+// to a mail server, over a mail protocol, or to an asset or over a link
+// tagged with any of AlertingTags. Other external entities, e.g. a backup or
+// analytics service, do not notify anybody by themselves.
+func hasAlertingPath(monitoring model.TechnicalAsset, settings *Settings) bool {
+	for _, commLink := range monitoring.CommunicationLinks {
+		target := model.ParsedModelRoot.TechnicalAssets[commLink.TargetId]
+		if target.Technology == model.MailServer || commLink.Protocol == model.SMTP || commLink.Protocol == model.SMTP_encrypted ||
+			target.IsTaggedWithAny(settings.AlertingTags...) || commLink.IsTaggedWithAny(settings.AlertingTags...) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r Rule) createRisk(technicalAsset model.TechnicalAsset, platforms []model.TechnicalAsset, via string, settings *Settings) model.Risk {
+	titles := make([]string, 0, len(platforms))
+	for _, platform := range platforms {
+		titles = append(titles, platform.Title)
+	}
+	monitoredBy := "by <b>" + strings.Join(titles, "</b>, <b>") + "</b>"
+	if len(via) > 0 {
+		monitoredBy = "via " + via
+	}
+	title := rulekit.TitleAt("Missing Alerting Path", technicalAsset) + ": monitored only " + monitoredBy + " without alerting"
+	impact := rulekit.MaxImpact(rulekit.ImpactFromConfidentiality(technicalAsset.HighestConfidentiality()),
+		rulekit.ImpactFromCriticality(technicalAsset.HighestIntegrity()),
+		rulekit.ImpactFromCriticality(technicalAsset.HighestAvailability()))
+	return rulekit.NewRisk(r.Category(), title).
+		Rating(settings.Likelihood.RiskExploitationLikelihood, impact).
+		TechnicalAsset(technicalAsset.Id).
+		DataBreach(model.Improbable).
+		Build()
+}
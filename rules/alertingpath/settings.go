@@ -0,0 +1,37 @@
+package alertingpath
+
+import (
+	"github.com/Otyg/threagile-rules/internal/config"
+	"github.com/threagile/threagile/model"
+)
+
+// Settings of the rule. Assets reaching MinimumConfidentiality or
+// MinimumCriticality need an alerting path, targets and links tagged with any
+// of AlertingTags provide one.
+type Settings struct {
+	MinimumConfidentiality config.Confidentiality `yaml:"minimum-confidentiality"`
+	MinimumCriticality     config.Criticality     `yaml:"minimum-criticality"`
+	AlertingTags           []string               `yaml:"alerting-tags"`
+	Likelihood             config.Likelihood      `yaml:"likelihood"`
+}
+
+func defaultSettings() config.Settings {
+	return &Settings{
+		MinimumConfidentiality: config.Confidentiality{Confidentiality: model.StrictlyConfidential},
+		MinimumCriticality:     config.Criticality{Criticality: model.MissionCritical},
+		AlertingTags:           []string{"alerting", "on-call"},
+		Likelihood:             config.Likelihood{RiskExploitationLikelihood: model.Likely},
+	}
+}
+
+func (s *Settings) Validate() error {
+	return config.ValidateTags("alerting-tags", s.AlertingTags)
+}
+
+func settings() *Settings {
+	return config.Get(Rule("").Category().Id).(*Settings)
+}
+
+func init() {
+	config.Register(Rule("").Category().Id, defaultSettings)
+}
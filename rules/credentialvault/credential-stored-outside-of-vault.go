@@ -0,0 +1,126 @@
+// Package credentialvault implements the credential-stored-outside-of-vault risk rule.
+package credentialvault
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/Otyg/threagile-rules/internal/rulekit"
+	"github.com/threagile/threagile/model"
+)
+
+type Rule string
+
+func (r Rule) Category() model.RiskCategory {
+	return model.RiskCategory{
+		Id:                         "credential-stored-outside-of-vault",
+		Title:                      "Credential Stored Outside Of Vault",
+		Description:                "Secret data, such as credentials and encryption keys, must be protected and managed in a secure way to minimize the risk of exposure. The recommended solution is to keep secret data in a dedicated system (vault) and only store access credentials to this system on other technical assets.",
+		Impact:                     "If a hardcoded secret is exposed considerable work must be done to rotate it",
+		ASVS:                       "v4.0.2-1.6.3 - Cryptographic Architectural Requirements, v4.0.2-6.4 - Secret Management",
+		CheatSheet:                 "https://cheatsheetseries.owasp.org/cheatsheets/Cryptographic_Storage_Cheat_Sheet.html#key-management, https://cheatsheetseries.owasp.org/cheatsheets/Key_Management_Cheat_Sheet.html#key-management-lifecycle-best-practices",
+		Action:                     "Secret management",
+		Mitigation:                 "Manage secrets and credentials according to ASVS and the cheat sheets referenced",
+		Check:                      "Is secret data (i.e. credentials) protected well enough? Has relevant parts of referenced ASVS and cheat sheets been applied?",
+		Function:                   model.Operations,
+		STRIDE:                     model.InformationDisclosure,
+		DetectionLogic:             "Data assets tagged with any of the supported tags stored or processed by, or received over a communication link by, a technical asset that is not a vault.",
+		RiskAssessment:             "One risk per credential and holder. Impact and likelihood are based on the lifetime tag of the credential, rotation lowers the likelihood, automatic more than manual, while a hardcoded credential is never considered rotated. Conflicting tags are reported as a model failure and the worst of them is assumed. The likelihood is lowered for copies only processed or transferred and for well protected holders, and raised for holders facing the internet, multi-tenant or on physical machines. The exposure score of the credential, summed over all holders and transferring links and shown in the title, raises the likelihood when it reaches the high-exposure setting, and the breach probability accounts for the number of copies.",
+		FalsePositives:             "Stored autorotated credentials with short lifetime can be considered a false positive after individual review.",
+		ModelFailurePossibleReason: true,
+		CWE:                        522,
+	}
+}
+
+var lifetimeTags = []string{hardcodedTag, unlimitedTag, longTag, shortTag, autoRotationTag, manualRotationTag}
+
+func (r Rule) SupportedTags() []string {
+	return append(append([]string{}, settings().CredentialTags...), lifetimeTags...)
+}
+
+// IsCredential reports whether the data asset is tagged as a credential, by
+// the credential tags or a credential-lifetime tag.
+func IsCredential(data model.DataAsset) bool {
+	return data.IsTaggedWithAny(Rule("").SupportedTags()...)
+}
+
+// Credentials returns the data assets tagged as credentials, sorted by title.
+func Credentials() []model.DataAsset {
+	result := make([]model.DataAsset, 0)
+	for _, data := range model.SortedDataAssetsByTitle() {
+		if IsCredential(data) {
+			result = append(result, data)
+		}
+	}
+	return result
+}
+
+var tagDescriptions = map[string]string{
+	"credential-lifetime:unknown/hardcoded": "The life time of the credential is unknown and it is probably hardcoded and hard or impossible to rotate",
+	"credential-lifetime:unlimited":         "The credential has no specified life-time and won't expire",
+	"credential-lifetime:long":              "The credential has a long life-time (months or more) before it expires",
+	"credential-lifetime:short":             "The credential has a short life-time (less than a month) before it expires",
+	"credential-lifetime:auto-rotation":     "The credential is rotated by automation",
+	"credential-lifetime:manual-rotation":   "The credential is rotated manually",
+}
+
+func (r Rule) DescribeTag(tag string) string {
+	return rulekit.DescribeTag(tag, tagDescriptions)
+}
+
+func (r Rule) GenerateRisks() []model.Risk {
+	risks := make([]model.Risk, 0)
+	settings := settings()
+	for _, data := range Credentials() {
+		assessment := assess(data.Tags, settings)
+		if len(assessment.conflicts) > 0 {
+			risks = append(risks, r.createConflictRisk(data, assessment.conflicts))
+		}
+		exploitationImpact := assessment.impact
+		exploitationProbability := assessment.likelihood
+		dataBreachProbability := assessment.dataBreachProbability
+		exposure := credentialExposure(data)
+		dataBreachProbability = exposure.breachProbability(dataBreachProbability, settings.ManyCopies)
+		for _, holder := range exposure.holders {
+			likelihood := holder.adjust(exploitationProbability, exposure, settings.HighExposure)
+			breachProbability := dataBreachProbability
+			if holder.Confidentiality == model.StrictlyConfidential && holder.Encryption != model.NoneEncryption {
+				// Assume that a technical asset classed for Strictly Confidential is well protected
+				likelihood = rulekit.LowerLikelihood(likelihood)
+				breachProbability = model.Improbable
+			}
+			risks = append(risks, r.createRisk(holder, data, exposure, exploitationImpact, likelihood, breachProbability))
+		}
+	}
+	return risks
+}
+
+func (r Rule) createRisk(holder holder, data model.DataAsset, exposure exposure, impact model.RiskExploitationImpact, probability model.RiskExploitationLikelihood, dataProbability model.DataBreachProbability) model.Risk {
+	title := rulekit.TitleAt("Credential "+holder.holding.String()+" outside of vault", holder.TechnicalAsset) +
+		": <b>" + data.Title + "</b> with exposure score " + strconv.Itoa(exposure.score) + " over " + copies(len(exposure.holders))
+	return rulekit.NewRisk(r.Category(), title).
+		Rating(probability, impact).
+		TechnicalAsset(holder.Id).
+		DataAsset(data.Id).
+		DataBreach(dataProbability, holder.Id).
+		IdentifiedBy(data.Id, holder.Id).
+		Build()
+}
+
+// createConflictRisk reports contradicting lifetime tags as a model failure,
+// the credential is still rated assuming the worst of them.
+func (r Rule) createConflictRisk(data model.DataAsset, conflicts []string) model.Risk {
+	title := "<b>Conflicting credential lifetime tags</b> at <b>" + data.Title + "</b>: " + strings.Join(conflicts, "; ")
+	return rulekit.NewRisk(r.Category(), title).
+		Rating(model.Unlikely, model.LowImpact).
+		DataAsset(data.Id).
+		IdentifiedBy(data.Id, "conflicting-tags").
+		Build()
+}
+
+func copies(count int) string {
+	if count == 1 {
+		return "1 copy"
+	}
+	return strconv.Itoa(count) + " copies"
+}
@@ -0,0 +1,147 @@
+package credentialvault
+
+import (
+	"github.com/Otyg/threagile-rules/internal/rulekit"
+	"github.com/threagile/threagile/model"
+)
+
+// holding is how a technical asset holds a copy of a credential, from the
+// most to the least persistent.
+type holding int
+
+const (
+	stored      holding = iota
+	processed           // kept in memory while used
+	transferred         // only received over a communication link
+)
+
+func (h holding) String() string {
+	return [...]string{"stored", "processed", "transferred"}[h]
+}
+
+// holder is an in-scope technical asset, other than a vault, holding a copy
+// of a credential.
+type holder struct {
+	model.TechnicalAsset
+	holding holding
+}
+
+// exposure sums up where a credential is exposed. Every holder and every link
+// transferring the credential adds to the score, more so when stored, facing
+// the internet, multi-tenant, on physical machines or sent unencrypted.
+type exposure struct {
+	holders []holder
+	links   []model.CommunicationLink
+	score   int
+}
+
+func credentialExposure(data model.DataAsset) exposure {
+	result := exposure{holders: make([]holder, 0), links: transferringLinks(data)}
+	for _, id := range model.SortedTechnicalAssetIDs() {
+		technicalAsset := model.ParsedModelRoot.TechnicalAssets[id]
+		if technicalAsset.OutOfScope || technicalAsset.Technology == model.Vault {
+			continue
+		}
+		var current holder
+		switch {
+		case model.Contains(technicalAsset.DataAssetsStored, data.Id):
+			current = holder{technicalAsset, stored}
+		case model.Contains(technicalAsset.DataAssetsProcessed, data.Id):
+			current = holder{technicalAsset, processed}
+		case receives(technicalAsset, data, result.links):
+			current = holder{technicalAsset, transferred}
+		default:
+			continue
+		}
+		result.holders = append(result.holders, current)
+		result.score += current.score()
+	}
+	for _, commLink := range result.links {
+		result.score++
+		if !commLink.Protocol.IsEncrypted() {
+			result.score++
+		}
+	}
+	return result
+}
+
+func (h holder) score() int {
+	score := 1
+	if h.holding == stored {
+		score++
+	}
+	if h.Internet {
+		score++
+	}
+	if h.MultiTenant {
+		score++
+	}
+	if h.Machine == model.Physical {
+		score++
+	}
+	return score
+}
+
+// adjust rates the likelihood of the credential leaking from the holder:
+// copies only in memory or in transit are less likely to leak, internet
+// facing, multi-tenant and physical assets, where the credential can be
+// extracted from the hardware, more likely. A credential exposed with a score
+// of at least highExposure is more likely to leak from every holder.
+func (h holder) adjust(likelihood model.RiskExploitationLikelihood, e exposure, highExposure int) model.RiskExploitationLikelihood {
+	if e.score >= highExposure {
+		likelihood = rulekit.RaiseLikelihood(likelihood)
+	}
+	if h.holding != stored {
+		likelihood = rulekit.LowerLikelihood(likelihood)
+	}
+	if h.Internet {
+		likelihood = rulekit.RaiseLikelihood(likelihood)
+	}
+	if h.MultiTenant {
+		likelihood = rulekit.RaiseLikelihood(likelihood)
+	}
+	if h.Machine == model.Physical {
+		likelihood = rulekit.RaiseLikelihood(likelihood)
+	}
+	return likelihood
+}
+
+// breachProbability accounts for the number of copies: a single copy lowers
+// the probability, ManyCopies or more raise it.
+func (e exposure) breachProbability(probability model.DataBreachProbability, manyCopies int) model.DataBreachProbability {
+	switch {
+	case len(e.holders) >= manyCopies:
+		return rulekit.RaiseBreachProbability(probability)
+	case len(e.holders) == 1:
+		return rulekit.LowerBreachProbability(probability)
+	default:
+		return probability
+	}
+}
+
+// transferringLinks returns the links sending or receiving the data asset,
+// sorted by source asset id and title.
+func transferringLinks(data model.DataAsset) []model.CommunicationLink {
+	result := make([]model.CommunicationLink, 0)
+	for _, id := range model.SortedTechnicalAssetIDs() {
+		for _, commLink := range model.ParsedModelRoot.TechnicalAssets[id].CommunicationLinksSorted() {
+			if model.Contains(commLink.DataAssetsSent, data.Id) || model.Contains(commLink.DataAssetsReceived, data.Id) {
+				result = append(result, commLink)
+			}
+		}
+	}
+	return result
+}
+
+// receives reports whether the technical asset is the receiving end of any of
+// the links for the data asset: the target when it is sent, the source when
+// it is received.
+func receives(technicalAsset model.TechnicalAsset, data model.DataAsset, links []model.CommunicationLink) bool {
+	for _, commLink := range links {
+		if commLink.TargetId == technicalAsset.Id && model.Contains(commLink.DataAssetsSent, data.Id) ||
+			commLink.SourceId == technicalAsset.Id && model.Contains(commLink.DataAssetsReceived, data.Id) {
+			return true
+		}
+	}
+	return false
+}
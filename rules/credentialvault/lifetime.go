@@ -0,0 +1,108 @@
+package credentialvault
+
+import (
+	"strings"
+
+	"github.com/Otyg/threagile-rules/internal/config"
+	"github.com/Otyg/threagile-rules/internal/rulekit"
+	"github.com/threagile/threagile/model"
+)
+
+const (
+	hardcodedTag      = "credential-lifetime:unknown/hardcoded"
+	unlimitedTag      = "credential-lifetime:unlimited"
+	longTag           = "credential-lifetime:long"
+	shortTag          = "credential-lifetime:short"
+	manualRotationTag = "credential-lifetime:manual-rotation"
+	autoRotationTag   = "credential-lifetime:auto-rotation"
+)
+
+// lifetimes are ordered from the worst to the best, when a credential is
+// tagged with several the worst one is assumed.
+var lifetimes = []struct {
+	tag    string
+	rating func(Lifetime) config.Rating
+}{
+	{hardcodedTag, func(l Lifetime) config.Rating { return l.Hardcoded }},
+	{unlimitedTag, func(l Lifetime) config.Rating { return l.Unlimited }},
+	{longTag, func(l Lifetime) config.Rating { return l.Long }},
+	{shortTag, func(l Lifetime) config.Rating { return l.Short }},
+}
+
+// rotations are ordered from the weakest to the strongest, when a credential
+// is tagged with both the weakest one is assumed. Besides lowering the
+// likelihood by the configured steps, rotation limits how long a leaked copy
+// is of use, automatic rotation more so than manual.
+var rotations = []struct {
+	tag                   string
+	steps                 func(Rotation) int
+	dataBreachProbability model.DataBreachProbability
+}{
+	{manualRotationTag, func(r Rotation) int { return r.Manual }, model.Possible},
+	{autoRotationTag, func(r Rotation) int { return r.Auto }, model.Improbable},
+}
+
+// assessment is the rating of a credential by its lifetime and rotation tags.
+type assessment struct {
+	likelihood            model.RiskExploitationLikelihood
+	impact                model.RiskExploitationImpact
+	dataBreachProbability model.DataBreachProbability
+	// conflicts describe contradicting tags, the rating then assumes the
+	// worst of them.
+	conflicts []string
+}
+
+// assess rates a credential. Lifetime and rotation are independent: the
+// lifetime gives the rating, and rotation lowers the likelihood by the steps
+// configured for it. Rotation also lowers the breach probability, manual
+// rotation to possible and automatic rotation to improbable, so automatic
+// rotation always rates better. A hardcoded credential cannot be rotated, so
+// that tag dominates. Credentials without a lifetime tag are rated as
+// hardcoded unless they are rotated; then they are taken not to expire by
+// themselves.
+func assess(tags []string, settings *Settings) assessment {
+	result := assessment{dataBreachProbability: model.Probable, conflicts: make([]string, 0)}
+	taggedLifetimes := make([]string, 0)
+	rating, rated := config.Rating{}, false
+	for _, lifetime := range lifetimes {
+		if model.ContainsCaseInsensitiveAny(tags, lifetime.tag) {
+			taggedLifetimes = append(taggedLifetimes, lifetime.tag)
+			if !rated {
+				rating, rated = lifetime.rating(settings.Lifetime), true
+			}
+		}
+	}
+	taggedRotations := make([]string, 0)
+	steps, rotatedBreachProbability := 0, result.dataBreachProbability
+	for _, rotation := range rotations {
+		if model.ContainsCaseInsensitiveAny(tags, rotation.tag) {
+			taggedRotations = append(taggedRotations, rotation.tag)
+			if len(taggedRotations) == 1 {
+				steps, rotatedBreachProbability = rotation.steps(settings.Rotation), rotation.dataBreachProbability
+			}
+		}
+	}
+	if len(taggedLifetimes) > 1 {
+		result.conflicts = append(result.conflicts, "several lifetimes "+strings.Join(taggedLifetimes, ", "))
+	}
+	if len(taggedRotations) > 1 {
+		result.conflicts = append(result.conflicts, "both "+strings.Join(taggedRotations, " and "))
+	}
+	hardcoded := model.ContainsCaseInsensitiveAny(tags, hardcodedTag)
+	if hardcoded && len(taggedRotations) > 0 {
+		result.conflicts = append(result.conflicts, "hardcoded but "+strings.Join(taggedRotations, ", "))
+	}
+	switch {
+	case hardcoded || (!rated && len(taggedRotations) == 0):
+		rating, steps, rotatedBreachProbability = settings.Lifetime.Hardcoded, 0, result.dataBreachProbability
+	case !rated:
+		rating = settings.Lifetime.Unlimited
+	}
+	result.likelihood = rating.Likelihood.RiskExploitationLikelihood
+	result.impact = rating.Impact.RiskExploitationImpact
+	for i := 0; i < steps; i++ {
+		result.likelihood = rulekit.LowerLikelihood(result.likelihood)
+	}
+	result.dataBreachProbability = rotatedBreachProbability
+	return result
+}
@@ -0,0 +1,75 @@
+package credentialvault
+
+import (
+	"testing"
+
+	"github.com/threagile/threagile/model"
+)
+
+func TestAssess(t *testing.T) {
+	cases := []struct {
+		tags       []string
+		likelihood model.RiskExploitationLikelihood
+		impact     model.RiskExploitationImpact
+		breach     model.DataBreachProbability
+		conflicts  int
+	}{
+		{[]string{"credential"}, model.Frequent, model.HighImpact, model.Probable, 0},
+		{[]string{manualRotationTag}, model.VeryLikely, model.MediumImpact, model.Possible, 0},
+		{[]string{autoRotationTag}, model.Likely, model.MediumImpact, model.Improbable, 0},
+		{[]string{hardcodedTag}, model.Frequent, model.HighImpact, model.Probable, 0},
+		{[]string{hardcodedTag, manualRotationTag}, model.Frequent, model.HighImpact, model.Probable, 1},
+		{[]string{hardcodedTag, autoRotationTag}, model.Frequent, model.HighImpact, model.Probable, 1},
+		{[]string{unlimitedTag}, model.Frequent, model.MediumImpact, model.Probable, 0},
+		{[]string{unlimitedTag, manualRotationTag}, model.VeryLikely, model.MediumImpact, model.Possible, 0},
+		{[]string{unlimitedTag, autoRotationTag}, model.Likely, model.MediumImpact, model.Improbable, 0},
+		{[]string{longTag}, model.VeryLikely, model.MediumImpact, model.Probable, 0},
+		{[]string{longTag, manualRotationTag}, model.Likely, model.MediumImpact, model.Possible, 0},
+		{[]string{longTag, autoRotationTag}, model.Unlikely, model.MediumImpact, model.Improbable, 0},
+		{[]string{shortTag}, model.Likely, model.MediumImpact, model.Probable, 0},
+		{[]string{shortTag, manualRotationTag}, model.Unlikely, model.MediumImpact, model.Possible, 0},
+		{[]string{shortTag, autoRotationTag}, model.Unlikely, model.MediumImpact, model.Improbable, 0},
+		{[]string{"Credential-Lifetime:Long", "credential-lifetime:auto-rotation"}, model.Unlikely, model.MediumImpact, model.Improbable, 0},
+		{[]string{shortTag, longTag}, model.VeryLikely, model.MediumImpact, model.Probable, 1},
+		{[]string{longTag, manualRotationTag, autoRotationTag}, model.Likely, model.MediumImpact, model.Possible, 1},
+		{[]string{hardcodedTag, shortTag, manualRotationTag, autoRotationTag}, model.Frequent, model.HighImpact, model.Probable, 3},
+	}
+	settings := defaultSettings().(*Settings)
+	for _, c := range cases {
+		result := assess(c.tags, settings)
+		if result.likelihood != c.likelihood || result.impact != c.impact || result.dataBreachProbability != c.breach || len(result.conflicts) != c.conflicts {
+			t.Errorf("%v: expected %v/%v/%v with %d conflicts, got %v/%v/%v with %v",
+				c.tags, c.likelihood, c.impact, c.breach, c.conflicts, result.likelihood, result.impact, result.dataBreachProbability, result.conflicts)
+		}
+	}
+}
+
+func TestAssessRotationSteps(t *testing.T) {
+	settings := defaultSettings().(*Settings)
+	settings.Rotation = Rotation{Manual: 0, Auto: 1}
+	if result := assess([]string{unlimitedTag, manualRotationTag}, settings); result.likelihood != model.Frequent || result.dataBreachProbability != model.Possible {
+		t.Errorf("manual rotation without steps should only lower the breach probability, got %+v", result)
+	}
+	if result := assess([]string{unlimitedTag, autoRotationTag}, settings); result.likelihood != model.VeryLikely {
+		t.Errorf("auto rotation should lower the likelihood one step, got %+v", result)
+	}
+	settings.Rotation = Rotation{Manual: 2, Auto: 1}
+	if settings.Validate() == nil {
+		t.Error("expected auto rotation weaker than manual to be rejected")
+	}
+}
+
+func TestAutoRotationRatesBetterThanManual(t *testing.T) {
+	settings := defaultSettings().(*Settings)
+	for _, rotation := range []Rotation{{Manual: 1, Auto: 2}, {Manual: 1, Auto: 1}, {Manual: 0, Auto: 0}, {Manual: 3, Auto: 3}} {
+		settings.Rotation = rotation
+		for _, lifetime := range [][]string{{}, {unlimitedTag}, {longTag}, {shortTag}} {
+			manual := assess(append(append([]string{}, lifetime...), manualRotationTag), settings)
+			auto := assess(append(append([]string{}, lifetime...), autoRotationTag), settings)
+			if auto.likelihood > manual.likelihood || auto.impact != manual.impact || auto.dataBreachProbability >= manual.dataBreachProbability {
+				t.Errorf("%v with %+v: auto rotation %v/%v/%v must rate strictly better than manual %v/%v/%v", lifetime, rotation,
+					auto.likelihood, auto.impact, auto.dataBreachProbability, manual.likelihood, manual.impact, manual.dataBreachProbability)
+			}
+		}
+	}
+}
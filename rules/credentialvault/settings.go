@@ -0,0 +1,73 @@
+package credentialvault
+
+import (
+	"fmt"
+
+	"github.com/Otyg/threagile-rules/internal/config"
+	"github.com/threagile/threagile/model"
+)
+
+// Settings of the rule. Data assets tagged with any of CredentialTags or a
+// credential-lifetime tag are credentials; Lifetime rates them by their
+// lifetime tag and Rotation lowers the likelihood of rotated ones before
+// storage is taken into account. Credentials held by ManyCopies or more
+// technical assets are more probable to leak, and those with an exposure
+// score of at least HighExposure more likely.
+type Settings struct {
+	CredentialTags []string `yaml:"credential-tags"`
+	Lifetime       Lifetime `yaml:"lifetime"`
+	Rotation       Rotation `yaml:"rotation"`
+	ManyCopies     int      `yaml:"many-copies"`
+	HighExposure   int      `yaml:"high-exposure"`
+}
+
+// Lifetime rates a credential by its credential-lifetime tag. Hardcoded is
+// also used for credentials without a lifetime tag.
+type Lifetime struct {
+	Hardcoded config.Rating `yaml:"unknown/hardcoded"`
+	Unlimited config.Rating `yaml:"unlimited"`
+	Long      config.Rating `yaml:"long"`
+	Short     config.Rating `yaml:"short"`
+}
+
+// Rotation is the number of steps the rotation tags lower the likelihood.
+type Rotation struct {
+	Manual int `yaml:"manual"`
+	Auto   int `yaml:"auto"`
+}
+
+func defaultSettings() config.Settings {
+	return &Settings{
+		CredentialTags: []string{"credential"},
+		Lifetime: Lifetime{
+			Hardcoded: config.NewRating(model.Frequent, model.HighImpact),
+			Unlimited: config.NewRating(model.Frequent, model.MediumImpact),
+			Long:      config.NewRating(model.VeryLikely, model.MediumImpact),
+			Short:     config.NewRating(model.Likely, model.MediumImpact),
+		},
+		Rotation:     Rotation{Manual: 1, Auto: 2},
+		ManyCopies:   3,
+		HighExposure: 10,
+	}
+}
+
+func (s *Settings) Validate() error {
+	if s.Rotation.Manual < 0 || s.Rotation.Auto < s.Rotation.Manual {
+		return fmt.Errorf("rotation: auto (%d) must be at least manual (%d), which must not be negative", s.Rotation.Auto, s.Rotation.Manual)
+	}
+	if s.ManyCopies < 2 {
+		return fmt.Errorf("many-copies (%d) must be at least 2", s.ManyCopies)
+	}
+	if s.HighExposure < 1 {
+		return fmt.Errorf("high-exposure (%d) must be at least 1", s.HighExposure)
+	}
+	return config.ValidateTags("credential-tags", s.CredentialTags)
+}
+
+func settings() *Settings {
+	return config.Get(Rule("").Category().Id).(*Settings)
+}
+
+func init() {
+	config.Register(Rule("").Category().Id, defaultSettings)
+}
@@ -1,14 +1,14 @@
-package main
+// Package insecurehandling implements the insecure-handling-of-sensitive-data risk rule.
+package insecurehandling
 
 import (
+	"github.com/Otyg/threagile-rules/internal/rulekit"
 	"github.com/threagile/threagile/model"
 )
 
-type insecureHandlingOfSensitiveData string
+type Rule string
 
-var CustomRiskRule insecureHandlingOfSensitiveData
-
-func (r insecureHandlingOfSensitiveData) Category() model.RiskCategory {
+func (r Rule) Category() model.RiskCategory {
 	return model.RiskCategory{
 		Id:                         "insecure-handling-of-sensitive-data",
 		Title:                      "Insecure Handling of Sensitive Data",
@@ -29,11 +29,15 @@ func (r insecureHandlingOfSensitiveData) Category() model.RiskCategory {
 	}
 }
 
-func (r insecureHandlingOfSensitiveData) SupportedTags() []string {
+func (r Rule) SupportedTags() []string {
 	return []string{"PII"}
 }
 
-func (r insecureHandlingOfSensitiveData) GenerateRisks() []model.Risk {
+func (r Rule) DescribeTag(tag string) string {
+	return rulekit.DescribeTag(tag, nil)
+}
+
+func (r Rule) GenerateRisks() []model.Risk {
 	risks := make([]model.Risk, 0)
 	for _, technicalAsset := range model.SortedTechnicalAssetsByTitle() {
 		if technicalAsset.Confidentiality == model.StrictlyConfidential || technicalAsset.OutOfScope {
@@ -58,61 +62,31 @@ func (r insecureHandlingOfSensitiveData) GenerateRisks() []model.Risk {
 		}
 		for _, dataAsset := range technicalAsset.DataAssetsStoredSorted() {
 			if technicalAsset.Confidentiality < dataAsset.Confidentiality {
-				var exploitationImpact model.RiskExploitationImpact
-				switch dataAsset.Confidentiality {
-				case model.Internal:
-					exploitationImpact = model.LowImpact
-				case model.Restricted:
-					exploitationImpact = model.MediumImpact
-				case model.Confidential:
-					exploitationImpact = model.HighImpact
-				case model.StrictlyConfidential:
-					exploitationImpact = model.VeryHighImpact
-				}
+				exploitationImpact := rulekit.ImpactFromConfidentiality(dataAsset.Confidentiality)
 				storedDataAssetsAtRisk[dataAsset.Id] = true
-				risks = append(risks, createRisk(dataAsset.Confidentiality, technicalAsset, exploitationImpact, exploitationLikelihood, dataAsset.Id, dataBreachProbability))
+				risks = append(risks, r.createRisk(dataAsset.Confidentiality, technicalAsset, exploitationImpact, exploitationLikelihood, dataAsset.Id, dataBreachProbability))
 			}
 		}
 		for _, dataAsset := range technicalAsset.DataAssetsProcessedSorted() {
 			_, alreadyAtRisk := storedDataAssetsAtRisk[dataAsset.Id]
 			if !alreadyAtRisk && technicalAsset.Confidentiality < dataAsset.Confidentiality {
-				var exploitationImpact model.RiskExploitationImpact
-				switch dataAsset.Confidentiality {
-				case model.Internal:
-					exploitationImpact = model.LowImpact
-				case model.Restricted:
-					exploitationImpact = model.MediumImpact
-				case model.Confidential:
-					exploitationImpact = model.HighImpact
-				case model.StrictlyConfidential:
-					exploitationImpact = model.VeryHighImpact
-				}
-				if exploitationLikelihood > model.Unlikely {
-					exploitationLikelihood = exploitationLikelihood - 1
-				}
-				if dataBreachProbability > model.Improbable {
-					dataBreachProbability = dataBreachProbability - 1
-				}
-				risks = append(risks, createRisk(dataAsset.Confidentiality, technicalAsset, exploitationImpact, exploitationLikelihood, dataAsset.Id, dataBreachProbability))
+				exploitationImpact := rulekit.ImpactFromConfidentiality(dataAsset.Confidentiality)
+				exploitationLikelihood = rulekit.LowerLikelihood(exploitationLikelihood)
+				dataBreachProbability = rulekit.LowerBreachProbability(dataBreachProbability)
+				risks = append(risks, r.createRisk(dataAsset.Confidentiality, technicalAsset, exploitationImpact, exploitationLikelihood, dataAsset.Id, dataBreachProbability))
 			}
 		}
 	}
 	return risks
 }
 
-func createRisk(class model.Confidentiality, technicalAsset model.TechnicalAsset, impact model.RiskExploitationImpact, probability model.RiskExploitationLikelihood, mostCriticalDataId string, dataProbability model.DataBreachProbability) model.Risk {
+func (r Rule) createRisk(class model.Confidentiality, technicalAsset model.TechnicalAsset, impact model.RiskExploitationImpact, probability model.RiskExploitationLikelihood, mostCriticalDataId string, dataProbability model.DataBreachProbability) model.Risk {
 	title := "<b>Potential insecure handling of " + class.String() + " data</b> at <b>" + technicalAsset.Title + "</b>"
-	risk := model.Risk{
-		Category:                     CustomRiskRule.Category(),
-		Severity:                     model.CalculateSeverity(probability, impact),
-		ExploitationLikelihood:       probability,
-		ExploitationImpact:           impact,
-		Title:                        title,
-		MostRelevantTechnicalAssetId: technicalAsset.Id,
-		MostRelevantDataAssetId:      mostCriticalDataId,
-		DataBreachProbability:        dataProbability,
-		DataBreachTechnicalAssetIDs:  []string{technicalAsset.Id},
-	}
-	risk.SyntheticId = risk.Category.Id + "@" + mostCriticalDataId + "@" + technicalAsset.Id
-	return risk
+	return rulekit.NewRisk(r.Category(), title).
+		Rating(probability, impact).
+		TechnicalAsset(technicalAsset.Id).
+		DataAsset(mostCriticalDataId).
+		DataBreach(dataProbability, technicalAsset.Id).
+		IdentifiedBy(mostCriticalDataId, technicalAsset.Id).
+		Build()
 }
@@ -0,0 +1,11 @@
+package insecurehandling
+
+import (
+	"testing"
+
+	"github.com/Otyg/threagile-rules/internal/ruletest"
+)
+
+func TestGenerateRisks(t *testing.T) {
+	ruletest.RunFixtures(t, Rule(""))
+}
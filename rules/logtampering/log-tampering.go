@@ -0,0 +1,95 @@
+// Package logtampering implements the log-tampering risk rule.
+package logtampering
+
+import (
+	"strings"
+
+	"github.com/Otyg/threagile-rules/internal/rulekit"
+	"github.com/threagile/threagile/model"
+)
+
+type Rule string
+
+func (r Rule) Category() model.RiskCategory {
+	return model.RiskCategory{
+		Id:                         "log-tampering",
+		Title:                      "Log Tampering",
+		Description:                "Logs only support non-repudiation when they cannot be forged or removed. Unauthenticated or unencrypted log ingestion, or a monitoring platform not protecting the integrity of what it has received, lets an attacker who compromised a source, or sits between it and the platform, write false entries or erase traces.",
+		Impact:                     "Actions can be denied or hidden since the audit trail can no longer be trusted, which also hampers incident response.",
+		ASVS:                       "v4.0.2-7.3 - Log Protection Requirements",
+		CheatSheet:                 "https://cheatsheetseries.owasp.org/cheatsheets/Logging_Cheat_Sheet.html#protection",
+		Action:                     "Logging and monitoring",
+		Mitigation:                 "Authenticate and encrypt log ingestion with credentials that only allow appending, and keep received logs append-only on a platform rated for the integrity the sources need.",
+		Check:                      "Are logs protected against forging and deletion from the source to the monitoring platform?",
+		Function:                   model.Operations,
+		STRIDE:                     model.Tampering,
+		DetectionLogic:             "Communication links writing to in-scope monitoring assets, i.e. not read-only, which are unauthenticated, unencrypted or shared with business traffic, or whose target has an integrity rating below the minimum.",
+		RiskAssessment:             "One risk per link. Unauthenticated or unencrypted links are likely, both very likely, otherwise unlikely. The impact depends on the integrity of the source, at least medium since repudiation is at stake.",
+		FalsePositives:             "Links into monitoring assets used for other purposes than logs, or platforms protecting the integrity of logs by means not visible in the model, such as write-once storage.",
+		ModelFailurePossibleReason: false,
+		CWE:                        117,
+	}
+}
+
+func (r Rule) SupportedTags() []string {
+	return []string{}
+}
+
+func (r Rule) GenerateRisks() []model.Risk {
+	risks := make([]model.Risk, 0)
+	settings := settings()
+	for _, id := range model.SortedTechnicalAssetIDs() {
+		monitoring := model.ParsedModelRoot.TechnicalAssets[id]
+		if monitoring.OutOfScope || monitoring.Technology != model.Monitoring {
+			continue
+		}
+		for _, commLink := range rulekit.IncomingLinks(monitoring) {
+			if commLink.Readonly {
+				continue
+			}
+			source := model.ParsedModelRoot.TechnicalAssets[commLink.SourceId]
+			likelihood, reasons := rate(commLink, monitoring, settings)
+			if len(reasons) > 0 {
+				risks = append(risks, r.createRisk(source, monitoring, commLink, likelihood, reasons))
+			}
+		}
+	}
+	return risks
+}
+
+// rate returns the likelihood of tampering with the logs sent on the link and
+// the weaknesses making it possible.
+func rate(commLink model.CommunicationLink, monitoring model.TechnicalAsset, settings *Settings) (model.RiskExploitationLikelihood, []string) {
+	likelihood := model.Unlikely
+	reasons := make([]string, 0)
+	if commLink.Authentication == model.NoneAuthentication {
+		likelihood = model.Likely
+		reasons = append(reasons, "unauthenticated")
+	}
+	if !commLink.Protocol.IsEncrypted() {
+		if likelihood == model.Likely {
+			likelihood = model.VeryLikely
+		} else {
+			likelihood = model.Likely
+		}
+		reasons = append(reasons, "unencrypted")
+	}
+	if commLink.Usage == model.Business {
+		reasons = append(reasons, "shared with business traffic")
+	}
+	if monitoring.Integrity < settings.MinimumIntegrity.Criticality {
+		reasons = append(reasons, "stored with "+monitoring.Integrity.String()+" integrity")
+	}
+	return likelihood, reasons
+}
+
+func (r Rule) createRisk(source, monitoring model.TechnicalAsset, commLink model.CommunicationLink, likelihood model.RiskExploitationLikelihood, reasons []string) model.Risk {
+	title := "<b>Log Tampering</b> risk for logs from <b>" + source.Title + "</b> to <b>" + monitoring.Title + "</b> over <b>" + commLink.Title + "</b>: " + strings.Join(reasons, ", ")
+	return rulekit.NewRisk(r.Category(), title).
+		Rating(likelihood, rulekit.MaxImpact(model.MediumImpact, rulekit.ImpactFromCriticality(source.Integrity))).
+		TechnicalAsset(monitoring.Id).
+		CommunicationLink(commLink.Id).
+		DataBreach(model.Improbable).
+		IdentifiedBy(commLink.Id).
+		Build()
+}
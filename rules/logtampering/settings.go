@@ -0,0 +1,30 @@
+package logtampering
+
+import (
+	"github.com/Otyg/threagile-rules/internal/config"
+	"github.com/threagile/threagile/model"
+)
+
+// Settings of the rule. Monitoring assets with an integrity rating below
+// MinimumIntegrity are not trusted to keep received logs unchanged.
+type Settings struct {
+	MinimumIntegrity config.Criticality `yaml:"minimum-integrity"`
+}
+
+func defaultSettings() config.Settings {
+	return &Settings{
+		MinimumIntegrity: config.Criticality{Criticality: model.Critical},
+	}
+}
+
+func (s *Settings) Validate() error {
+	return nil
+}
+
+func settings() *Settings {
+	return config.Get(Rule("").Category().Id).(*Settings)
+}
+
+func init() {
+	config.Register(Rule("").Category().Id, defaultSettings)
+}
@@ -0,0 +1,116 @@
+package missingaudit
+
+import (
+	"github.com/Otyg/threagile-rules/internal/rulekit"
+	"github.com/threagile/threagile/model"
+)
+
+// Audit log evidence tags, on the technical asset or its links to monitoring.
+const (
+	auditLogTag      = "audit-log"
+	verifiedTag      = "audit-log:verified"
+	tamperEvidentTag = "audit-log:tamper-evident"
+)
+
+var tagDescriptions = map[string]string{
+	auditLogTag:      "The asset writes an audit log, not yet verified",
+	verifiedTag:      "The audit log of the asset has been verified to cover access and changes to its sensitive data",
+	tamperEvidentTag: "The audit log of the asset is protected against undetected changes, e.g. by hash chaining or write-once storage",
+}
+
+// aspect is what the audit log has to record: access to confidential data
+// or changes to data whose integrity matters.
+type aspect struct {
+	name        string
+	title       string
+	assetImpact func(technicalAsset model.TechnicalAsset, settings *Settings) (model.RiskExploitationImpact, bool)
+	dataImpact  func(dataAsset model.DataAsset, settings *Settings) (model.RiskExploitationImpact, bool)
+	// tamperEvident audit logs are needed for the finding to be dropped.
+	tamperEvident bool
+}
+
+var aspects = []aspect{
+	{"access", "access", accessAssetImpact, accessDataImpact, false},
+	{"change", "changes", changeAssetImpact, changeDataImpact, true},
+}
+
+// ratedData is a sensitive data asset and the impact of missing its audit
+// log.
+type ratedData struct {
+	model.DataAsset
+	impact model.RiskExploitationImpact
+}
+
+// sensitiveData returns the data assets processed or stored by the asset
+// which need an audit log of the aspect, processed ones first.
+func (a aspect) sensitiveData(technicalAsset model.TechnicalAsset, settings *Settings) []ratedData {
+	result := make([]ratedData, 0)
+	seen := make(map[string]bool)
+	for _, dataAsset := range rulekit.DataAssetsProcessedOrStored(technicalAsset) {
+		if impact, ok := a.dataImpact(dataAsset, settings); ok && !seen[dataAsset.Id] {
+			seen[dataAsset.Id] = true
+			result = append(result, ratedData{dataAsset, impact})
+		}
+	}
+	return result
+}
+
+func accessAssetImpact(technicalAsset model.TechnicalAsset, settings *Settings) (model.RiskExploitationImpact, bool) {
+	sensitive := technicalAsset.Confidentiality >= settings.MinimumConfidentiality.Confidentiality || technicalAsset.IsTaggedWithAny(settings.Tags...)
+	return rulekit.MaxImpact(model.MediumImpact, rulekit.ImpactFromConfidentiality(technicalAsset.Confidentiality)), sensitive
+}
+
+func accessDataImpact(dataAsset model.DataAsset, settings *Settings) (model.RiskExploitationImpact, bool) {
+	sensitive := dataAsset.Confidentiality >= settings.MinimumConfidentiality.Confidentiality || dataAsset.IsTaggedWithAny(settings.Tags...)
+	return rulekit.MaxImpact(model.MediumImpact, rulekit.ImpactFromConfidentiality(dataAsset.Confidentiality)), sensitive
+}
+
+func changeAssetImpact(technicalAsset model.TechnicalAsset, settings *Settings) (model.RiskExploitationImpact, bool) {
+	sensitive := technicalAsset.Integrity >= settings.MinimumIntegrity.Criticality
+	return rulekit.MaxImpact(model.MediumImpact, rulekit.ImpactFromCriticality(technicalAsset.Integrity)), sensitive
+}
+
+func changeDataImpact(dataAsset model.DataAsset, settings *Settings) (model.RiskExploitationImpact, bool) {
+	sensitive := dataAsset.Integrity >= settings.MinimumIntegrity.Criticality
+	return rulekit.MaxImpact(model.MediumImpact, rulekit.ImpactFromCriticality(dataAsset.Integrity)), sensitive
+}
+
+// rateEvidence rates how likely the audit log of the aspect is missing,
+// returning false when it is verified. Audit log tags count on the asset and
+// on its links to monitoring. Without tags a link to monitoring sending the
+// sensitive data assets, or any data asset when the asset is sensitive by its
+// own rating, is taken as a sign of audit logging.
+func rateEvidence(technicalAsset model.TechnicalAsset, aspect aspect, sensitiveData []ratedData) (model.RiskExploitationLikelihood, string, bool) {
+	tags := append([]string{}, technicalAsset.Tags...)
+	monitoringLinks := rulekit.MonitoringLinks(technicalAsset)
+	sendsData := false
+	for _, commLink := range monitoringLinks {
+		tags = append(tags, commLink.Tags...)
+		for _, dataId := range commLink.DataAssetsSent {
+			if len(sensitiveData) == 0 {
+				sendsData = true
+			}
+			for _, dataAsset := range sensitiveData {
+				if dataAsset.Id == dataId {
+					sendsData = true
+				}
+			}
+		}
+	}
+	verified := model.ContainsCaseInsensitiveAny(tags, verifiedTag)
+	tamperEvident := model.ContainsCaseInsensitiveAny(tags, tamperEvidentTag)
+	switch {
+	case verified && (tamperEvident || !aspect.tamperEvident):
+		return model.Unlikely, "", false
+	case verified:
+		return model.Unlikely, "audit log not tamper-evident", true
+	case model.ContainsCaseInsensitiveAny(tags, auditLogTag) || tamperEvident:
+		return model.Unlikely, "audit log not verified", true
+	case sendsData:
+		return model.Unlikely, "sent to monitoring, audit log not stated", true
+	case len(monitoringLinks) > 0:
+		return model.Likely, "sensitive data not sent to monitoring", true
+	default:
+		return model.VeryLikely, "not sent to monitoring", true
+	}
+}
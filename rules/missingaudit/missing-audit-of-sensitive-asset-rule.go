@@ -0,0 +1,106 @@
+// Package missingaudit implements the missing-audit-log-of-sensitive-asset risk rule.
+package missingaudit
+
+import (
+	"strings"
+
+	"github.com/Otyg/threagile-rules/internal/rulekit"
+	"github.com/threagile/threagile/model"
+)
+
+type Rule string
+
+func (r Rule) Category() model.RiskCategory {
+	return model.RiskCategory{
+		Id:                         "missing-audit-log-of-sensitive-asset",
+		Title:                      "Missing Audit Log Of Sensitive Asset",
+		Description:                "Access to sensitive assets must be monitored and logged. For confidential assets access should be logged and assets where integrity is important changes must be logged.",
+		Impact:                     "Investigations and audits of access and/or changes to sensitive data will be harder",
+		ASVS:                       "v4.0.2-7.1 - Log content",
+		CheatSheet:                 "https://cheatsheetseries.owasp.org/cheatsheets/Logging_Cheat_Sheet.html",
+		Action:                     "Logging and monitoring",
+		Mitigation:                 "Implement auditlogging for all sensitive assets",
+		Check:                      "Are access to sensitive assets logged according to ASVS and cheat sheet?",
+		Function:                   model.Development,
+		STRIDE:                     model.Repudiation,
+		DetectionLogic:             "In-scope technical assets whose own or processed and stored data assets' confidentiality or tags call for access auditing, or whose integrity calls for change auditing, without verified audit logging stated by audit-log tags on the asset or its links to monitoring.",
+		RiskAssessment:             "One risk per asset for access and one for change auditing, or with per-data-asset one per sensitive data asset processed or stored by the asset, naming the data assets driving the rating. The impact depends on the confidentiality respectively integrity of the asset and its data. The likelihood is very likely without a link to monitoring, likely when the sensitive data assets are not sent over it and unlikely when they are or audit logging is stated but not verified. Change auditing also needs a tamper-evident audit log to be dropped.",
+		FalsePositives:             "None",
+		ModelFailurePossibleReason: false,
+		CWE:                        1009,
+	}
+}
+
+func (r Rule) SupportedTags() []string {
+	return append(append([]string{}, settings().Tags...), auditLogTag, verifiedTag, tamperEvidentTag)
+}
+
+func (r Rule) DescribeTag(tag string) string {
+	return rulekit.DescribeTag(tag, tagDescriptions)
+}
+
+func (r Rule) GenerateRisks() []model.Risk {
+	risks := make([]model.Risk, 0)
+	settings := settings()
+	for _, id := range model.SortedTechnicalAssetIDs() {
+		technicalAsset := model.ParsedModelRoot.TechnicalAssets[id]
+		if technicalAsset.OutOfScope || technicalAsset.Technology == model.Monitoring {
+			continue
+		}
+		for _, aspect := range aspects {
+			assetImpact, sensitiveAsset := aspect.assetImpact(technicalAsset, settings)
+			data := aspect.sensitiveData(technicalAsset, settings)
+			if settings.PerDataAsset && len(data) > 0 {
+				for _, dataAsset := range data {
+					if likelihood, reason, ok := rateEvidence(technicalAsset, aspect, []ratedData{dataAsset}); ok {
+						risks = append(risks, r.createRisk(technicalAsset, aspect, []ratedData{dataAsset}, true, reason, dataAsset.impact, likelihood))
+					}
+				}
+				continue
+			}
+			if !sensitiveAsset && len(data) == 0 {
+				continue
+			}
+			impact := model.MediumImpact
+			if sensitiveAsset {
+				impact = assetImpact
+			}
+			for _, dataAsset := range data {
+				impact = rulekit.MaxImpact(impact, dataAsset.impact)
+			}
+			if likelihood, reason, ok := rateEvidence(technicalAsset, aspect, data); ok {
+				risks = append(risks, r.createRisk(technicalAsset, aspect, data, false, reason, impact, likelihood))
+			}
+		}
+	}
+	return risks
+}
+
+// createRisk names the sensitive data assets in the title and references the
+// most sensitive one. Risks per data asset are identified by it too, so they
+// can be accepted one by one.
+func (r Rule) createRisk(technicalAsset model.TechnicalAsset, aspect aspect, data []ratedData, perDataAsset bool, reason string, impact model.RiskExploitationImpact, probability model.RiskExploitationLikelihood) model.Risk {
+	title := rulekit.TitleAt("Missing audit log", technicalAsset) + " for " + aspect.title
+	var mostRelevant ratedData
+	if len(data) > 0 {
+		titles := make([]string, 0, len(data))
+		for _, dataAsset := range data {
+			titles = append(titles, dataAsset.Title)
+			if len(mostRelevant.Id) == 0 || dataAsset.impact > mostRelevant.impact {
+				mostRelevant = dataAsset
+			}
+		}
+		title += " to <b>" + strings.Join(titles, "</b>, <b>") + "</b>"
+	}
+	builder := rulekit.NewRisk(r.Category(), title+": "+reason).
+		Rating(probability, impact).
+		TechnicalAsset(technicalAsset.Id).
+		DataAsset(mostRelevant.Id).
+		DataBreach(model.Improbable)
+	if perDataAsset {
+		builder.IdentifiedBy(mostRelevant.Id, technicalAsset.Id, aspect.name)
+	} else {
+		builder.IdentifiedBy(technicalAsset.Id, aspect.name)
+	}
+	return builder.Build()
+}
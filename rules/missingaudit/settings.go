@@ -0,0 +1,37 @@
+package missingaudit
+
+import (
+	"github.com/Otyg/threagile-rules/internal/config"
+	"github.com/threagile/threagile/model"
+)
+
+// Settings of the rule. Assets and data assets are sensitive when their
+// confidentiality or integrity reaches the minimum or they are tagged with any
+// of Tags. With PerDataAsset there is one risk per sensitive data asset
+// instead of one per technical asset.
+type Settings struct {
+	Tags                   []string               `yaml:"tags"`
+	MinimumConfidentiality config.Confidentiality `yaml:"minimum-confidentiality"`
+	MinimumIntegrity       config.Criticality     `yaml:"minimum-integrity"`
+	PerDataAsset           bool                   `yaml:"per-data-asset"`
+}
+
+func defaultSettings() config.Settings {
+	return &Settings{
+		Tags:                   []string{"PII"},
+		MinimumConfidentiality: config.Confidentiality{Confidentiality: model.Restricted},
+		MinimumIntegrity:       config.Criticality{Criticality: model.Important},
+	}
+}
+
+func (s *Settings) Validate() error {
+	return config.ValidateTags("tags", s.Tags)
+}
+
+func settings() *Settings {
+	return config.Get(Rule("").Category().Id).(*Settings)
+}
+
+func init() {
+	config.Register(Rule("").Category().Id, defaultSettings)
+}
@@ -0,0 +1,104 @@
+package missingmonitoring
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/threagile/threagile/model"
+)
+
+// coverage is how a technical asset without a direct link to a monitoring
+// asset still gets its logs there.
+type coverage struct {
+	// path holds the technical assets from the asset, or the collector, to
+	// the monitoring asset.
+	path []model.TechnicalAsset
+	// link is the first communication link of the path.
+	link model.CommunicationLink
+	// collector is set when the path starts at a collector in the same trust
+	// boundary or shared runtime, instead of at the asset.
+	collector string
+}
+
+func (c coverage) describe() string {
+	titles := make([]string, 0, len(c.path))
+	for _, technicalAsset := range c.path {
+		titles = append(titles, "<b>"+technicalAsset.Title+"</b>")
+	}
+	if len(c.collector) > 0 {
+		return c.collector + ": " + strings.Join(titles, " → ")
+	}
+	return strings.Join(titles, " → ")
+}
+
+// pathToMonitoring searches the shortest path of at most MaxHops
+// communication links from the asset to a monitoring asset, following only
+// links tagged with LogTags when OnlyTaggedLinks is set. Links are visited
+// sorted by title so the same path is found every time.
+func pathToMonitoring(technicalAsset model.TechnicalAsset, settings *Settings) (coverage, bool) {
+	type step struct {
+		path []model.TechnicalAsset
+		link model.CommunicationLink
+	}
+	visited := map[string]bool{technicalAsset.Id: true}
+	queue := []step{{path: []model.TechnicalAsset{technicalAsset}}}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		if len(current.path) > settings.MaxHops {
+			continue
+		}
+		for _, commLink := range current.path[len(current.path)-1].CommunicationLinksSorted() {
+			if settings.OnlyTaggedLinks && !commLink.IsTaggedWithAny(settings.LogTags...) {
+				continue
+			}
+			if visited[commLink.TargetId] {
+				continue
+			}
+			visited[commLink.TargetId] = true
+			next := step{path: append(append([]model.TechnicalAsset{}, current.path...), model.ParsedModelRoot.TechnicalAssets[commLink.TargetId]), link: current.link}
+			if len(current.path) == 1 {
+				next.link = commLink
+			}
+			if next.path[len(next.path)-1].Technology == model.Monitoring {
+				return coverage{path: next.path, link: next.link}, true
+			}
+			queue = append(queue, next)
+		}
+	}
+	return coverage{}, false
+}
+
+// collectorCoverage looks for a technical asset tagged with CollectorTags in
+// the trust boundary or shared runtime of the asset which itself reaches a
+// monitoring asset.
+func collectorCoverage(technicalAsset model.TechnicalAsset, settings *Settings) (coverage, bool) {
+	if trustBoundary, ok := model.DirectContainingTrustBoundaryMappedByTechnicalAssetId[technicalAsset.Id]; ok {
+		if result, ok := collectorAmong(technicalAsset, trustBoundary.TechnicalAssetsInside, settings); ok {
+			result.collector = "collector in trust boundary <b>" + trustBoundary.Title + "</b>"
+			return result, true
+		}
+	}
+	if sharedRuntime, ok := model.DirectContainingSharedRuntimeMappedByTechnicalAssetId[technicalAsset.Id]; ok {
+		if result, ok := collectorAmong(technicalAsset, sharedRuntime.TechnicalAssetsRunning, settings); ok {
+			result.collector = "collector in shared runtime <b>" + sharedRuntime.Title + "</b>"
+			return result, true
+		}
+	}
+	return coverage{}, false
+}
+
+func collectorAmong(technicalAsset model.TechnicalAsset, ids []string, settings *Settings) (coverage, bool) {
+	sorted := append([]string{}, ids...)
+	sort.Strings(sorted)
+	for _, id := range sorted {
+		collector := model.ParsedModelRoot.TechnicalAssets[id]
+		if id == technicalAsset.Id || !collector.IsTaggedWithAny(settings.CollectorTags...) {
+			continue
+		}
+		if result, ok := pathToMonitoring(collector, settings); ok {
+			return result, true
+		}
+	}
+	return coverage{}, false
+}
@@ -0,0 +1,170 @@
+// Package missingmonitoring implements the missing-monitoring risk rule.
+package missingmonitoring
+
+import (
+	"strings"
+
+	"github.com/Otyg/threagile-rules/internal/rulekit"
+	"github.com/threagile/threagile/model"
+)
+
+type Rule string
+
+func (r Rule) Category() model.RiskCategory {
+	return model.RiskCategory{
+		Id:                         "missing-monitoring",
+		Title:                      "Missing Monitoring",
+		Description:                "The model is missing a monitoring target for collecting, analysis and alerting on logdata and events.",
+		Impact:                     "Without an external platform for monitoring an attacker might go undetected and might be able to tamper with logfiles etc.",
+		ASVS:                       "v4.0.2-7 - Error Handling and Logging Verification Requirements",
+		CheatSheet:                 "",
+		Action:                     "Logging and monitoring",
+		Mitigation:                 "Send logdata and other events to an external platform for storage and analysis.",
+		Check:                      "Are relevant logs sent to an external monitoring platform?",
+		Function:                   model.Architecture,
+		STRIDE:                     model.Repudiation,
+		DetectionLogic:             "Models without a Monitoring platform, reported once for the model, and in-scope technical assets which neither send to a monitoring asset, directly or forwarded by other assets within the maximum number of hops, nor have a log collector in their trust boundary or shared runtime forwarding to one.",
+		RiskAssessment:             "The risk rating depends on the sensitivity of the technical assets and data processed. Assets only monitored indirectly are reported with low severity, naming the path providing the coverage so it can be verified.",
+		FalsePositives:             "None",
+		ModelFailurePossibleReason: true,
+		CWE:                        778,
+	}
+}
+
+func (r Rule) SupportedTags() []string {
+	settings := settings()
+	return append(append([]string{}, settings.LogTags...), settings.CollectorTags...)
+}
+
+var tagDescriptions = map[string]string{
+	"logs":          "The link carries logs, followed when only-tagged-links is set",
+	"log-collector": "The asset collects the logs of its trust boundary or shared runtime and forwards them",
+}
+
+func (r Rule) DescribeTag(tag string) string {
+	return rulekit.DescribeTag(tag, tagDescriptions)
+}
+
+func (r Rule) GenerateRisks() []model.Risk {
+	for _, techAsset := range model.ParsedModelRoot.TechnicalAssets {
+		if techAsset.Technology == model.Monitoring {
+			return r.unmonitoredAssets()
+		}
+	}
+	return r.missingPlatform()
+}
+
+// missingPlatform reports the model lacking a monitoring platform as a
+// whole, rated by the most sensitive in-scope asset and listing all
+// sensitive ones.
+func (r Rule) missingPlatform() []model.Risk {
+	impact := model.MediumImpact
+	probability := model.Likely
+	var mostRelevantAsset model.TechnicalAsset
+	sensitiveAssets := make([]string, 0)
+	for _, id := range model.SortedTechnicalAssetIDs() { // use the sorted one to always get the same tech asset with highest sensitivity as example asset
+		techAsset := model.ParsedModelRoot.TechnicalAssets[id]
+		if techAsset.OutOfScope {
+			continue
+		}
+		assetImpact, assetProbability := rate(techAsset.HighestConfidentiality(), techAsset.HighestIntegrity(), techAsset.HighestAvailability())
+		if assetImpact > model.MediumImpact {
+			sensitiveAssets = append(sensitiveAssets, techAsset.Title)
+		}
+		if assetImpact > impact {
+			impact, probability = assetImpact, assetProbability
+		}
+		// just for referencing the most interesting asset
+		if len(mostRelevantAsset.Id) == 0 || techAsset.HighestSensitivityScore() > mostRelevantAsset.HighestSensitivityScore() {
+			mostRelevantAsset = techAsset
+		}
+	}
+	if len(mostRelevantAsset.Id) == 0 {
+		return []model.Risk{}
+	}
+	title := "<b>Missing Monitoring (Logging platform)</b> in the threat model"
+	if len(sensitiveAssets) > 0 {
+		title += ", affecting the sensitive assets <b>" + strings.Join(sensitiveAssets, "</b>, <b>") + "</b>"
+	}
+	return []model.Risk{rulekit.NewRisk(r.Category(), title).
+		Rating(probability, impact).
+		TechnicalAsset(mostRelevantAsset.Id).
+		DataBreach(model.Improbable).
+		IdentifiedBy(mostRelevantAsset.Id).
+		Build()}
+}
+
+// unmonitoredAssets reports the in-scope assets whose logs do not reach the
+// monitoring platform.
+func (r Rule) unmonitoredAssets() []model.Risk {
+	risks := make([]model.Risk, 0)
+	settings := settings()
+	for _, id := range model.SortedTechnicalAssetIDs() {
+		techAsset := model.ParsedModelRoot.TechnicalAssets[id]
+		if techAsset.OutOfScope || techAsset.Technology == model.Monitoring || rulekit.SendsToMonitoring(techAsset) {
+			continue
+		}
+		if coverage, ok := indirectCoverage(techAsset, settings); ok {
+			risks = append(risks, r.createIndirectRisk(techAsset, coverage))
+		} else {
+			impact, probability := rate(techAsset.Confidentiality, techAsset.Integrity, techAsset.Availability)
+			risks = append(risks, r.createRisk(techAsset, impact, probability))
+		}
+	}
+	return risks
+}
+
+// rate maps the highest of the ratings to the impact and likelihood of
+// missing monitoring.
+func rate(confidentiality model.Confidentiality, integrity model.Criticality, availability model.Criticality) (model.RiskExploitationImpact, model.RiskExploitationLikelihood) {
+	switch {
+	case confidentiality == model.StrictlyConfidential || integrity == model.MissionCritical || availability == model.MissionCritical:
+		return model.VeryHighImpact, model.VeryLikely
+	case confidentiality == model.Confidential || integrity == model.Critical || availability == model.Critical:
+		return model.HighImpact, model.VeryLikely
+	default:
+		return model.MediumImpact, model.Likely
+	}
+}
+
+func (r Rule) createRisk(technicalAsset model.TechnicalAsset, impact model.RiskExploitationImpact, probability model.RiskExploitationLikelihood) model.Risk {
+	title := "<b>Missing Monitoring (Logging platform)</b> in the threat model (referencing asset <b>" + technicalAsset.Title + "</b> as an example)"
+	return rulekit.NewRisk(r.Category(), title).
+		Rating(probability, impact).
+		TechnicalAsset(technicalAsset.Id).
+		DataBreach(model.Improbable).
+		IdentifiedBy(technicalAsset.Id).
+		Build()
+}
+
+// IndirectMonitoring returns the monitoring asset the logs of a technical
+// asset without a direct monitoring link reach, forwarded by other assets or a
+// collector within the configured limits of the rule, and a description of
+// the path for titles.
+func IndirectMonitoring(technicalAsset model.TechnicalAsset) (model.TechnicalAsset, string, bool) {
+	coverage, ok := indirectCoverage(technicalAsset, settings())
+	if !ok {
+		return model.TechnicalAsset{}, "", false
+	}
+	return coverage.path[len(coverage.path)-1], coverage.describe(), true
+}
+
+// indirectCoverage returns the path forwarding the logs of the asset to a
+// monitoring asset, from the asset itself or from a collector next to it.
+func indirectCoverage(technicalAsset model.TechnicalAsset, settings *Settings) (coverage, bool) {
+	if result, ok := pathToMonitoring(technicalAsset, settings); ok {
+		return result, true
+	}
+	return collectorCoverage(technicalAsset, settings)
+}
+
+func (r Rule) createIndirectRisk(technicalAsset model.TechnicalAsset, coverage coverage) model.Risk {
+	title := "<b>Indirect Monitoring</b> of <b>" + technicalAsset.Title + "</b> via " + coverage.describe()
+	return rulekit.NewRisk(r.Category(), title).
+		Rating(model.Unlikely, model.LowImpact).
+		TechnicalAsset(technicalAsset.Id).
+		CommunicationLink(coverage.link.Id).
+		DataBreach(model.Improbable).
+		IdentifiedBy(technicalAsset.Id).
+		Build()
+}
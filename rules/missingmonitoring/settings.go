@@ -0,0 +1,46 @@
+package missingmonitoring
+
+import (
+	"fmt"
+
+	"github.com/Otyg/threagile-rules/internal/config"
+)
+
+// Settings of the rule. Assets are monitored when a path of at most MaxHops
+// communication links leads to a monitoring asset, or when a technical asset
+// tagged with any of CollectorTags in their trust boundary or shared runtime
+// has such a path. With OnlyTaggedLinks set the path must consist of links
+// tagged with any of LogTags.
+type Settings struct {
+	MaxHops         int      `yaml:"max-hops"`
+	OnlyTaggedLinks bool     `yaml:"only-tagged-links"`
+	LogTags         []string `yaml:"log-tags"`
+	CollectorTags   []string `yaml:"collector-tags"`
+}
+
+func defaultSettings() config.Settings {
+	return &Settings{
+		MaxHops:         3,
+		OnlyTaggedLinks: false,
+		LogTags:         []string{"logs"},
+		CollectorTags:   []string{"log-collector"},
+	}
+}
+
+func (s *Settings) Validate() error {
+	if s.MaxHops < 1 {
+		return fmt.Errorf("max-hops (%d) must be at least 1", s.MaxHops)
+	}
+	if err := config.ValidateTags("log-tags", s.LogTags); err != nil {
+		return err
+	}
+	return config.ValidateTags("collector-tags", s.CollectorTags)
+}
+
+func settings() *Settings {
+	return config.Get(Rule("").Category().Id).(*Settings)
+}
+
+func init() {
+	config.Register(Rule("").Category().Id, defaultSettings)
+}
@@ -0,0 +1,225 @@
+// Package misspelledtag implements the misspelled-custom-tag risk rule.
+package misspelledtag
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/Otyg/threagile-rules/internal/rulekit"
+	"github.com/threagile/threagile/model"
+)
+
+type Rule string
+
+// KnownRules returns the rules whose supported tags make up the vocabulary
+// the model is checked against. Package rules sets it to all rules of this
+// repository; it cannot be imported here without an import cycle.
+var KnownRules = func() []model.CustomRiskRule {
+	return []model.CustomRiskRule{}
+}
+
+func (r Rule) Category() model.RiskCategory {
+	return model.RiskCategory{
+		Id:                         "misspelled-custom-tag",
+		Title:                      "Misspelled Custom Tag",
+		Description:                "Tags are matched exactly by the custom rules, a tag that is close to but not one of the tags the rules understand is silently ignored and the rules relying on it assess the element as untagged.",
+		Impact:                     "Risks depending on the tag are rated as if the tag was missing, e.g. a credential with a short lifetime is rated as hardcoded or sensitive data is not recognized as PII.",
+		ASVS:                       "",
+		CheatSheet:                 "",
+		Action:                     "Threat model quality",
+		Mitigation:                 "Correct the tag to the suggested one, or remove it if it is not meant for the custom rules.",
+		Check:                      "Are the tags of the model spelled the way the custom rules expect?",
+		Function:                   model.Architecture,
+		STRIDE:                     model.InformationDisclosure,
+		DetectionLogic:             "Tags of data assets, technical assets and communication links which are not supported by any custom rule but differ from a supported tag only by separators or a few characters, and values other than the known ones of supported tags with a closed set of values such as credential-lifetime:. Tags with a prefix taking any value, such as crypto:, retention: or accept:, are not checked.",
+		RiskAssessment:             "Always low, the finding is about the model and not the system.",
+		FalsePositives:             "Tags used for other purposes than the custom rules that happen to be close to a supported tag.",
+		ModelFailurePossibleReason: true,
+		CWE:                        1068,
+	}
+}
+
+func (r Rule) SupportedTags() []string {
+	return []string{}
+}
+
+func (r Rule) GenerateRisks() []model.Risk {
+	risks := make([]model.Risk, 0)
+	vocabulary := newVocabulary(KnownRules())
+	maxDistance := settings().MaxDistance
+	for _, id := range model.SortedKeysOfDataAssets() {
+		dataAsset := model.ParsedModelRoot.DataAssets[id]
+		for _, tag := range dataAsset.Tags {
+			if suggestions, ok := vocabulary.suggest(tag, maxDistance); ok {
+				risks = append(risks, r.createRisk(dataAsset.Title, tag, suggestions).
+					DataAsset(dataAsset.Id).
+					IdentifiedBy(dataAsset.Id, tag).
+					Build())
+			}
+		}
+	}
+	for _, id := range model.SortedTechnicalAssetIDs() {
+		technicalAsset := model.ParsedModelRoot.TechnicalAssets[id]
+		for _, tag := range technicalAsset.Tags {
+			if suggestions, ok := vocabulary.suggest(tag, maxDistance); ok {
+				risks = append(risks, r.createRisk(technicalAsset.Title, tag, suggestions).
+					TechnicalAsset(technicalAsset.Id).
+					IdentifiedBy(technicalAsset.Id, tag).
+					Build())
+			}
+		}
+		for _, commLink := range technicalAsset.CommunicationLinksSorted() {
+			for _, tag := range commLink.Tags {
+				if suggestions, ok := vocabulary.suggest(tag, maxDistance); ok {
+					risks = append(risks, r.createRisk(commLink.Title, tag, suggestions).
+						TechnicalAsset(technicalAsset.Id).
+						CommunicationLink(commLink.Id).
+						IdentifiedBy(commLink.Id, tag).
+						Build())
+				}
+			}
+		}
+	}
+	return risks
+}
+
+func (r Rule) createRisk(elementTitle, tag string, suggestions []string) *rulekit.RiskBuilder {
+	title := "<b>Misspelled tag</b> <b>" + tag + "</b> at <b>" + elementTitle + "</b>, did you mean "
+	if len(suggestions) > 1 {
+		title += "one of "
+	}
+	title += "<b>" + strings.Join(suggestions, "</b>, <b>") + "</b>?"
+	return rulekit.NewRisk(r.Category(), title).
+		Rating(model.Unlikely, model.LowImpact)
+}
+
+// vocabulary holds the supported tags of the known rules, lowercased as
+// threagile lowercases the tags of the model, mapped to their spelling in
+// the rule.
+type vocabulary struct {
+	tags     map[string]string
+	prefixes map[string]bool // the part up to and including the colon of tags with a value
+	open     map[string]bool // prefixes of tags taking any value
+}
+
+func newVocabulary(rules []model.CustomRiskRule) vocabulary {
+	result := vocabulary{tags: make(map[string]string), prefixes: make(map[string]bool), open: make(map[string]bool)}
+	for _, prefix := range rulekit.AcceptanceTagPrefixes() {
+		result.open[strings.ToLower(prefix)] = true
+	}
+	for _, rule := range rules {
+		if prefixer, ok := rule.(rulekit.OpenTagPrefixer); ok {
+			for _, prefix := range prefixer.OpenTagPrefixes() {
+				result.open[strings.ToLower(prefix)] = true
+			}
+		}
+		for _, tag := range append(rule.SupportedTags(), rulekit.AcceptanceTags(rule.Category().Id)...) {
+			result.tags[strings.ToLower(tag)] = tag
+			if colon := strings.Index(tag, ":"); colon > 0 {
+				result.prefixes[strings.ToLower(tag[:colon+1])] = true
+			}
+		}
+	}
+	return result
+}
+
+// suggest returns the supported tags the given tag most likely was meant to
+// be. Supported tags and tags not close to any supported tag are fine. A tag
+// with the prefix of a supported tag but an unknown value is always reported,
+// unless the prefix takes any value: it is suggested the closest value when
+// that is close enough, and all values of the prefix otherwise.
+func (v vocabulary) suggest(tag string, maxDistance int) ([]string, bool) {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	if _, ok := v.tags[tag]; ok {
+		return nil, false
+	}
+	candidates := v.sortedTags()
+	closed := false
+	if colon := strings.Index(tag, ":"); colon > 0 && (v.prefixes[tag[:colon+1]] || v.open[tag[:colon+1]]) {
+		if v.open[tag[:colon+1]] {
+			return nil, false
+		}
+		sameBase := make([]string, 0)
+		for _, candidate := range candidates {
+			if strings.HasPrefix(candidate, tag[:colon+1]) {
+				sameBase = append(sameBase, candidate)
+			}
+		}
+		candidates, closed = sameBase, true
+	}
+	for _, candidate := range candidates {
+		if normalize(candidate) == normalize(tag) {
+			return []string{v.tags[candidate]}, true
+		}
+	}
+	best := closest(tag, candidates)
+	if distance := editDistance(tag, best); len(best) > 0 && distance <= maxDistance && 2*distance < len(best) {
+		return []string{v.tags[best]}, true
+	}
+	if closed {
+		values := make([]string, 0, len(candidates))
+		for _, candidate := range candidates {
+			values = append(values, v.tags[candidate])
+		}
+		return values, true
+	}
+	return nil, false
+}
+
+func (v vocabulary) sortedTags() []string {
+	result := make([]string, 0, len(v.tags))
+	for tag := range v.tags {
+		result = append(result, tag)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// normalize drops the separators people tend to mix up.
+func normalize(tag string) string {
+	return strings.NewReplacer("-", "", "_", "", " ", "", ".", "").Replace(tag)
+}
+
+// closest returns the candidate with the smallest edit distance to the tag,
+// the first one in the given order on a tie.
+func closest(tag string, candidates []string) string {
+	best, bestDistance := "", -1
+	for _, candidate := range candidates {
+		if distance := editDistance(tag, candidate); bestDistance < 0 || distance < bestDistance {
+			best, bestDistance = candidate, distance
+		}
+	}
+	return best
+}
+
+// editDistance is the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	first, second := []rune(a), []rune(b)
+	previous := make([]int, len(second)+1)
+	current := make([]int, len(second)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(first); i++ {
+		current[0] = i
+		for j := 1; j <= len(second); j++ {
+			cost := 1
+			if first[i-1] == second[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(second)]
+}
+
+func min(values ...int) int {
+	result := values[0]
+	for _, value := range values[1:] {
+		if value < result {
+			result = value
+		}
+	}
+	return result
+}
@@ -0,0 +1,14 @@
+package misspelledtag_test
+
+import (
+	"testing"
+
+	"github.com/Otyg/threagile-rules/internal/ruletest"
+	// sets misspelledtag.KnownRules
+	_ "github.com/Otyg/threagile-rules/rules"
+	"github.com/Otyg/threagile-rules/rules/misspelledtag"
+)
+
+func TestGenerateRisks(t *testing.T) {
+	ruletest.RunFixtures(t, misspelledtag.Rule(""))
+}
@@ -0,0 +1,35 @@
+package misspelledtag
+
+import (
+	"fmt"
+
+	"github.com/Otyg/threagile-rules/internal/config"
+)
+
+// Settings of the rule. Tags within MaxDistance edits of a supported tag are
+// reported as misspelled, as long as less than half of the tag differs.
+// Unknown values of prefixes with a closed set of values are always reported.
+type Settings struct {
+	MaxDistance int `yaml:"max-distance"`
+}
+
+func defaultSettings() config.Settings {
+	return &Settings{
+		MaxDistance: 2,
+	}
+}
+
+func (s *Settings) Validate() error {
+	if s.MaxDistance < 0 {
+		return fmt.Errorf("max-distance (%d) must not be negative", s.MaxDistance)
+	}
+	return nil
+}
+
+func settings() *Settings {
+	return config.Get(Rule("").Category().Id).(*Settings)
+}
+
+func init() {
+	config.Register(Rule("").Category().Id, defaultSettings)
+}
@@ -0,0 +1,159 @@
+// Package monitoringprotection implements the
+// insufficient-monitoring-platform-protection risk rule.
+package monitoringprotection
+
+import (
+	"strings"
+
+	"github.com/Otyg/threagile-rules/internal/rulekit"
+	"github.com/Otyg/threagile-rules/rules/accidentallogging"
+	"github.com/threagile/threagile/model"
+)
+
+type Rule string
+
+func (r Rule) Category() model.RiskCategory {
+	return model.RiskCategory{
+		Id:                         "insufficient-monitoring-platform-protection",
+		Title:                      "Insufficient Monitoring Platform Protection",
+		Description:                "Logs of assets handling sensitive data may contain that data. A monitoring platform rated or encrypted below the data it may receive becomes the weakest place the data is kept.",
+		Impact:                     "Sensitive data in the logs can be read or changed with less effort than at the source, bypassing the protection of the source.",
+		ASVS:                       "v4.0.2-7.3 - Log Protection",
+		CheatSheet:                 "https://cheatsheetseries.owasp.org/cheatsheets/Logging_Cheat_Sheet.html#protection",
+		Action:                     "Logging and monitoring",
+		Mitigation:                 "Protect the monitoring platform at least as well as the most sensitive data it may receive, or ensure the sources do not log that data.",
+		Check:                      "Is the monitoring platform rated and encrypted according to the data the sources may log?",
+		Function:                   model.Operations,
+		STRIDE:                     model.InformationDisclosure,
+		DetectionLogic:             "In-scope monitoring assets whose confidentiality rating is below that of the sensitive data the sources the accidental-logging-of-sensitive-data rule checks process or store or send to them, whose integrity rating is below that of the sensitive data or of any data in-scope assets send to them, or which are unencrypted while the sensitive data is at least the minimum confidentiality for encryption.",
+		RiskAssessment:             "One risk per monitoring asset, listing the offending sources. The impact depends on the confidentiality or integrity of the data exceeding the rating of the monitoring asset.",
+		FalsePositives:             "Sources which are known not to log the sensitive data they handle.",
+		ModelFailurePossibleReason: true,
+		CWE:                        532,
+	}
+}
+
+func (r Rule) SupportedTags() []string {
+	return []string{}
+}
+
+func (r Rule) GenerateRisks() []model.Risk {
+	risks := make([]model.Risk, 0)
+	for _, id := range model.SortedTechnicalAssetIDs() {
+		technicalAsset := model.ParsedModelRoot.TechnicalAssets[id]
+		if technicalAsset.OutOfScope || technicalAsset.Technology != model.Monitoring {
+			continue
+		}
+		if risk, ok := r.createRisk(technicalAsset); ok {
+			risks = append(risks, risk)
+		}
+	}
+	return risks
+}
+
+// createRisk compares the monitoring asset with the sensitive data each
+// source may log to it and the data sent to it. It returns false when no
+// source exceeds it.
+func (r Rule) createRisk(monitoring model.TechnicalAsset) (model.Risk, bool) {
+	settings := settings()
+	impact := model.LowImpact
+	var mostRelevantData model.DataAsset
+	var mostRelevantLink model.CommunicationLink
+	gaps := make([]string, 0)
+	sources := make([]string, 0)
+	for _, commLink := range rulekit.IncomingLinks(monitoring) {
+		source := model.ParsedModelRoot.TechnicalAssets[commLink.SourceId]
+		if source.OutOfScope || source.Technology == model.Monitoring {
+			continue
+		}
+		sensitive := make([]model.DataAsset, 0)
+		if accidentallogging.IsSensitiveSource(source) {
+			sensitive = loggableData(source, commLink)
+		}
+		offending := false
+		for _, data := range appendSent(sensitive, commLink) {
+			exceeds := false
+			isSensitive := containsDataAsset(sensitive, data)
+			if isSensitive && data.Confidentiality > monitoring.Confidentiality {
+				gaps = appendOnce(gaps, "confidentiality")
+				impact = rulekit.MaxImpact(impact, rulekit.ImpactFromConfidentiality(data.Confidentiality))
+				exceeds = true
+			}
+			if data.Integrity > monitoring.Integrity {
+				gaps = appendOnce(gaps, "integrity")
+				impact = rulekit.MaxImpact(impact, rulekit.ImpactFromCriticality(data.Integrity))
+				exceeds = true
+			}
+			if isSensitive && monitoring.Encryption == model.NoneEncryption && data.Confidentiality >= settings.EncryptionConfidentiality.Confidentiality {
+				gaps = appendOnce(gaps, "encryption")
+				impact = rulekit.MaxImpact(impact, rulekit.ImpactFromConfidentiality(data.Confidentiality))
+				exceeds = true
+			}
+			if !exceeds {
+				continue
+			}
+			offending = true
+			if len(mostRelevantData.Id) == 0 || data.Confidentiality > mostRelevantData.Confidentiality ||
+				(data.Confidentiality == mostRelevantData.Confidentiality && data.Integrity > mostRelevantData.Integrity) {
+				mostRelevantData, mostRelevantLink = data, commLink
+			}
+		}
+		if offending {
+			sources = appendOnce(sources, source.Title)
+		}
+	}
+	if len(sources) == 0 {
+		return model.Risk{}, false
+	}
+	title := "<b>Insufficient " + strings.Join(gaps, ", ") + "</b> of <b>" + monitoring.Title + "</b> for logs from <b>" + strings.Join(sources, "</b>, <b>") + "</b>"
+	return rulekit.NewRisk(r.Category(), title).
+		Rating(settings.Likelihood.RiskExploitationLikelihood, impact).
+		TechnicalAsset(monitoring.Id).
+		CommunicationLink(mostRelevantLink.Id).
+		DataAsset(mostRelevantData.Id).
+		DataBreach(model.Possible, monitoring.Id).
+		IdentifiedBy(monitoring.Id).
+		Build(), true
+}
+
+// loggableData returns the sensitive data the source processes or stores,
+// which may end up in its logs, and the sensitive data it sends on the link,
+// each data asset once.
+func loggableData(source model.TechnicalAsset, commLink model.CommunicationLink) []model.DataAsset {
+	candidates := rulekit.DataAssetsProcessedOrStored(source)
+	for _, data := range commLink.DataAssetsSentSorted() {
+		if !model.Contains(source.DataAssetsProcessed, data.Id) && !model.Contains(source.DataAssetsStored, data.Id) {
+			candidates = append(candidates, data)
+		}
+	}
+	return accidentallogging.SensitiveDataAssets(candidates)
+}
+
+// appendSent returns the data assets followed by the data sent on the link
+// which is not among them, as the integrity of all data sent to the
+// monitoring asset matters regardless of its sensitivity.
+func appendSent(dataAssets []model.DataAsset, commLink model.CommunicationLink) []model.DataAsset {
+	result := append(make([]model.DataAsset, 0, len(dataAssets)), dataAssets...)
+	for _, data := range commLink.DataAssetsSentSorted() {
+		if !containsDataAsset(result, data) {
+			result = append(result, data)
+		}
+	}
+	return result
+}
+
+func containsDataAsset(dataAssets []model.DataAsset, data model.DataAsset) bool {
+	for _, candidate := range dataAssets {
+		if candidate.Id == data.Id {
+			return true
+		}
+	}
+	return false
+}
+
+func appendOnce(list []string, value string) []string {
+	if model.Contains(list, value) {
+		return list
+	}
+	return append(list, value)
+}
@@ -0,0 +1,32 @@
+package monitoringprotection
+
+import (
+	"github.com/Otyg/threagile-rules/internal/config"
+	"github.com/threagile/threagile/model"
+)
+
+// Settings of the rule. Unencrypted monitoring assets are flagged when they
+// may receive data of at least EncryptionConfidentiality.
+type Settings struct {
+	EncryptionConfidentiality config.Confidentiality `yaml:"encryption-confidentiality"`
+	Likelihood                config.Likelihood      `yaml:"likelihood"`
+}
+
+func defaultSettings() config.Settings {
+	return &Settings{
+		EncryptionConfidentiality: config.Confidentiality{Confidentiality: model.Confidential},
+		Likelihood:                config.Likelihood{RiskExploitationLikelihood: model.Likely},
+	}
+}
+
+func (s *Settings) Validate() error {
+	return nil
+}
+
+func settings() *Settings {
+	return config.Get(Rule("").Category().Id).(*Settings)
+}
+
+func init() {
+	config.Register(Rule("").Category().Id, defaultSettings)
+}
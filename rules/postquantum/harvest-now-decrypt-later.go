@@ -0,0 +1,164 @@
+// Package postquantum implements the harvest-now-decrypt-later risk rule.
+package postquantum
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/Otyg/threagile-rules/internal/rulekit"
+	"github.com/threagile/threagile/model"
+)
+
+type Rule string
+
+func (r Rule) Category() model.RiskCategory {
+	return model.RiskCategory{
+		Id:                         "harvest-now-decrypt-later",
+		Title:                      "Harvest Now, Decrypt Later",
+		Description:                "Data that must stay secret for many years can be recorded today and decrypted once quantum computers break the asymmetric algorithms protecting it. RSA and elliptic curve algorithms such as ECDH and ECDSA are vulnerable.",
+		Impact:                     "Encrypted copies or recorded traffic of long-lived confidential data may be decrypted within its retention period.",
+		ASVS:                       "v4.0.3-6.2.3 - Stored cryptography: Algorithms, v4.0.3-6.2.6 - Cryptographic agility",
+		CheatSheet:                 "https://cheatsheetseries.owasp.org/cheatsheets/Cryptographic_Storage_Cheat_Sheet.html",
+		Action:                     "Cryptography",
+		Mitigation:                 "Plan the migration to post-quantum or hybrid key establishment (e.g. ML-KEM) for the storage and links handling the data, starting with the data with the longest retention.",
+		Check:                      "Is there a crypto migration plan covering the data before its retention period ends?",
+		Function:                   model.Architecture,
+		STRIDE:                     model.InformationDisclosure,
+		DetectionLogic:             "Data assets tagged with a retention:<years>y tag of at least the minimum retention and at least the minimum confidentiality, stored on encrypted in-scope technical assets or sent or received over encrypted communication links which are tagged with quantum-vulnerable crypto:<algorithm> tags and no post-quantum key encapsulation.",
+		RiskAssessment:             "Impact is based on the confidentiality of the data asset, the likelihood is the configured one since a practical attack is still years away.",
+		FalsePositives:             "Data whose confidentiality ends well before large quantum computers are expected, or links where the quantum-vulnerable algorithm is only used for authentication.",
+		ModelFailurePossibleReason: false,
+		CWE:                        327,
+	}
+}
+
+// RetentionTagPrefix starts the retention tags, e.g. retention:10y.
+const RetentionTagPrefix = "retention:"
+
+var retentionTags = []string{"retention:1y", "retention:3y", "retention:5y", "retention:7y", "retention:10y", "retention:15y", "retention:20y", "retention:30y", "retention:50y", "retention:permanent"}
+
+func (r Rule) SupportedTags() []string {
+	tags := append([]string{}, retentionTags...)
+	for _, algorithm := range rulekit.TaggedAlgorithms(rulekit.AlgorithmTags()) {
+		if algorithm.QuantumVulnerable || isPostQuantumKeyEncapsulation(algorithm) {
+			tags = append(tags, algorithm.Tag)
+		}
+	}
+	return tags
+}
+
+// OpenTagPrefixes lets any number of years be tagged, and algorithms missing
+// in the catalogue, which are not taken as quantum-vulnerable.
+func (r Rule) OpenTagPrefixes() []string {
+	return []string{RetentionTagPrefix, rulekit.CryptoTagPrefix}
+}
+
+func (r Rule) DescribeTag(tag string) string {
+	if strings.HasPrefix(strings.ToLower(tag), RetentionTagPrefix) {
+		return "The data must stay confidential for " + strings.TrimPrefix(strings.ToLower(tag), RetentionTagPrefix) + ", where y is years"
+	}
+	return rulekit.DescribeAlgorithmTag(tag)
+}
+
+func (r Rule) GenerateRisks() []model.Risk {
+	risks := make([]model.Risk, 0)
+	settings := settings()
+	for _, dataId := range model.SortedKeysOfDataAssets() {
+		data := model.ParsedModelRoot.DataAssets[dataId]
+		years, ok := retentionYears(data)
+		if !ok || years < settings.MinimumRetentionYears || data.Confidentiality < settings.MinimumConfidentiality.Confidentiality {
+			continue
+		}
+		for _, technicalAsset := range data.StoredByTechnicalAssetsSorted() {
+			if technicalAsset.OutOfScope || technicalAsset.Encryption == model.NoneEncryption {
+				continue
+			}
+			if algorithm, ok := quantumVulnerable(technicalAsset.Tags, data.Tags); ok {
+				risks = append(risks, r.createRisk(data, algorithm, "stored at <b>"+technicalAsset.Title+"</b>").
+					TechnicalAsset(technicalAsset.Id).
+					DataBreach(model.Improbable, technicalAsset.Id).
+					IdentifiedBy(data.Id, technicalAsset.Id).
+					Build())
+			}
+		}
+		for _, commLink := range transferringLinks(data) {
+			if algorithm, ok := quantumVulnerable(commLink.Tags); ok {
+				risks = append(risks, r.createRisk(data, algorithm, "transferred over <b>"+commLink.Title+"</b>").
+					TechnicalAsset(commLink.SourceId).
+					CommunicationLink(commLink.Id).
+					DataBreach(model.Improbable, commLink.SourceId, commLink.TargetId).
+					IdentifiedBy(data.Id, commLink.Id).
+					Build())
+			}
+		}
+	}
+	return risks
+}
+
+func (r Rule) createRisk(data model.DataAsset, algorithm rulekit.Algorithm, where string) *rulekit.RiskBuilder {
+	title := "<b>Harvest now, decrypt later</b> risk for <b>" + data.Title + "</b> " + where + " protected by <b>" + algorithm.Name() + "</b>"
+	return rulekit.NewRisk(r.Category(), title).
+		Rating(settings().Likelihood.RiskExploitationLikelihood, rulekit.ImpactFromConfidentiality(data.Confidentiality)).
+		DataAsset(data.Id)
+}
+
+// retentionYears reads the longest retention tag of the data asset,
+// retention:permanent counts as forever.
+func retentionYears(data model.DataAsset) (int, bool) {
+	longest, found := 0, false
+	for _, tag := range data.Tags {
+		value := strings.TrimPrefix(strings.ToLower(tag), RetentionTagPrefix)
+		if value == strings.ToLower(tag) {
+			continue
+		}
+		years := 0
+		if value == "permanent" {
+			years = int(^uint(0) >> 1)
+		} else if parsed, err := strconv.Atoi(strings.TrimSuffix(value, "y")); err == nil && strings.HasSuffix(value, "y") {
+			years = parsed
+		} else {
+			continue
+		}
+		if !found || years > longest {
+			longest, found = years, true
+		}
+	}
+	return longest, found
+}
+
+// quantumVulnerable returns the first quantum-vulnerable algorithm among the
+// tags, unless a post-quantum key encapsulation is used alongside it as in
+// hybrid key establishment.
+func quantumVulnerable(tags ...[]string) (rulekit.Algorithm, bool) {
+	algorithms := rulekit.TaggedAlgorithms(tags...)
+	for _, algorithm := range algorithms {
+		if isPostQuantumKeyEncapsulation(algorithm) {
+			return rulekit.Algorithm{}, false
+		}
+	}
+	for _, algorithm := range algorithms {
+		if algorithm.QuantumVulnerable {
+			return algorithm, true
+		}
+	}
+	return rulekit.Algorithm{}, false
+}
+
+func isPostQuantumKeyEncapsulation(algorithm rulekit.Algorithm) bool {
+	return strings.HasPrefix(algorithm.Name(), "ml-kem")
+}
+
+// transferringLinks returns the encrypted links sending or receiving the
+// data asset, sorted by id.
+func transferringLinks(data model.DataAsset) []model.CommunicationLink {
+	links := make([]model.CommunicationLink, 0)
+	for _, id := range model.SortedTechnicalAssetIDs() {
+		for _, commLink := range model.ParsedModelRoot.TechnicalAssets[id].CommunicationLinksSorted() {
+			if commLink.Protocol.IsEncrypted() &&
+				(model.Contains(commLink.DataAssetsSent, data.Id) || model.Contains(commLink.DataAssetsReceived, data.Id)) {
+				links = append(links, commLink)
+			}
+		}
+	}
+	return links
+}
@@ -0,0 +1,39 @@
+package postquantum
+
+import (
+	"fmt"
+
+	"github.com/Otyg/threagile-rules/internal/config"
+	"github.com/threagile/threagile/model"
+)
+
+// Settings of the rule. Data assets with at least MinimumConfidentiality and
+// a retention tag of at least MinimumRetentionYears are long-lived secrets.
+type Settings struct {
+	MinimumConfidentiality config.Confidentiality `yaml:"minimum-confidentiality"`
+	MinimumRetentionYears  int                    `yaml:"minimum-retention-years"`
+	Likelihood             config.Likelihood      `yaml:"likelihood"`
+}
+
+func defaultSettings() config.Settings {
+	return &Settings{
+		MinimumConfidentiality: config.Confidentiality{Confidentiality: model.Confidential},
+		MinimumRetentionYears:  10,
+		Likelihood:             config.Likelihood{RiskExploitationLikelihood: model.Unlikely},
+	}
+}
+
+func (s *Settings) Validate() error {
+	if s.MinimumRetentionYears < 0 {
+		return fmt.Errorf("minimum-retention-years (%d) must not be negative", s.MinimumRetentionYears)
+	}
+	return nil
+}
+
+func settings() *Settings {
+	return config.Get(Rule("").Category().Id).(*Settings)
+}
+
+func init() {
+	config.Register(Rule("").Category().Id, defaultSettings)
+}
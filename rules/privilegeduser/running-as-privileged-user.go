@@ -1,14 +1,14 @@
-package main
+// Package privilegeduser implements the running-as-privileged-user risk rule.
+package privilegeduser
 
 import (
+	"github.com/Otyg/threagile-rules/internal/rulekit"
 	"github.com/threagile/threagile/model"
 )
 
-type runningAsPrivilegedUser string
+type Rule string
 
-var CustomRiskRule runningAsPrivilegedUser
-
-func (r runningAsPrivilegedUser) Category() model.RiskCategory {
+func (r Rule) Category() model.RiskCategory {
 	return model.RiskCategory{
 		Id:                         "running-as-privileged-user",
 		Title:                      "Execution as Privileged User",
@@ -29,44 +29,45 @@ func (r runningAsPrivilegedUser) Category() model.RiskCategory {
 	}
 }
 
-func (r runningAsPrivilegedUser) SupportedTags() []string {
-	return []string{"non-root", "unprivileged", "isNotAdmin"}
+func (r Rule) SupportedTags() []string {
+	return settings().Tags
+}
+
+var tagDescriptions = map[string]string{
+	"non-root":     "The asset runs as a user other than root",
+	"unprivileged": "The asset runs as a user with least privileges",
+	"isNotAdmin":   "The asset runs as a user without administrative rights",
 }
 
-func (r runningAsPrivilegedUser) GenerateRisks() []model.Risk {
+func (r Rule) DescribeTag(tag string) string {
+	return rulekit.DescribeTag(tag, tagDescriptions)
+}
+
+func (r Rule) GenerateRisks() []model.Risk {
 	risks := make([]model.Risk, 0)
+	settings := settings()
 	for _, id := range model.SortedTechnicalAssetIDs() {
 		techAsset := model.ParsedModelRoot.TechnicalAssets[id]
 		if techAsset.OutOfScope || techAsset.Technology.IsClient() {
 			continue
 		}
-		if !techAsset.IsTaggedWithAny(CustomRiskRule.SupportedTags()...) {
-			var impact = model.MediumImpact
-			var likelihood = model.Likely
-			if techAsset.RAA < 0.2 {
-				impact = model.LowImpact
-				likelihood = model.Unlikely
-			} else if techAsset.RAA > 0.8 {
-				impact = model.HighImpact
-				likelihood = model.VeryLikely
+		if !techAsset.IsTaggedWithAny(r.SupportedTags()...) {
+			rating := settings.Medium
+			if techAsset.RAA < settings.LowRAA {
+				rating = settings.Low
+			} else if techAsset.RAA > settings.HighRAA {
+				rating = settings.High
 			}
-			risks = append(risks, createRisk(techAsset, impact, likelihood))
+			risks = append(risks, r.createRisk(techAsset, rating.Impact.RiskExploitationImpact, rating.Likelihood.RiskExploitationLikelihood))
 		}
 	}
 	return risks
 }
 
-func createRisk(technicalAsset model.TechnicalAsset, impact model.RiskExploitationImpact, likelihood model.RiskExploitationLikelihood) model.Risk {
-	title := "<b>Running as privileged user</b> risk at <b>" + technicalAsset.Title + "</b>"
-	risk := model.Risk{
-		Category:                     CustomRiskRule.Category(),
-		Severity:                     model.CalculateSeverity(likelihood, impact),
-		ExploitationLikelihood:       likelihood,
-		ExploitationImpact:           impact,
-		Title:                        title,
-		MostRelevantTechnicalAssetId: technicalAsset.Id,
-		DataBreachTechnicalAssetIDs:  []string{},
-	}
-	risk.SyntheticId = risk.Category.Id + "@" + technicalAsset.Id
-	return risk
+func (r Rule) createRisk(technicalAsset model.TechnicalAsset, impact model.RiskExploitationImpact, likelihood model.RiskExploitationLikelihood) model.Risk {
+	return rulekit.NewRisk(r.Category(), rulekit.TitleAt("Running as privileged user", technicalAsset)).
+		Rating(likelihood, impact).
+		TechnicalAsset(technicalAsset.Id).
+		IdentifiedBy(technicalAsset.Id).
+		Build()
 }
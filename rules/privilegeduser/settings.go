@@ -0,0 +1,45 @@
+package privilegeduser
+
+import (
+	"fmt"
+
+	"github.com/Otyg/threagile-rules/internal/config"
+	"github.com/threagile/threagile/model"
+)
+
+// Settings of the rule. Assets with an RAA below LowRAA get the Low rating,
+// above HighRAA the High rating and the Medium rating otherwise.
+type Settings struct {
+	Tags    []string      `yaml:"tags"`
+	LowRAA  float64       `yaml:"low-raa"`
+	HighRAA float64       `yaml:"high-raa"`
+	Low     config.Rating `yaml:"low"`
+	Medium  config.Rating `yaml:"medium"`
+	High    config.Rating `yaml:"high"`
+}
+
+func defaultSettings() config.Settings {
+	return &Settings{
+		Tags:    []string{"non-root", "unprivileged", "isNotAdmin"},
+		LowRAA:  0.2,
+		HighRAA: 0.8,
+		Low:     config.NewRating(model.Unlikely, model.LowImpact),
+		Medium:  config.NewRating(model.Likely, model.MediumImpact),
+		High:    config.NewRating(model.VeryLikely, model.HighImpact),
+	}
+}
+
+func (s *Settings) Validate() error {
+	if s.LowRAA < 0 || s.HighRAA < s.LowRAA {
+		return fmt.Errorf("low-raa (%v) must be positive and not above high-raa (%v)", s.LowRAA, s.HighRAA)
+	}
+	return config.ValidateTags("tags", s.Tags)
+}
+
+func settings() *Settings {
+	return config.Get(Rule("").Category().Id).(*Settings)
+}
+
+func init() {
+	config.Register(Rule("").Category().Id, defaultSettings)
+}
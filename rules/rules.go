@@ -0,0 +1,63 @@
+// Package rules lists the risk rules of this repository so they can be run
+// in-process, e.g. by cmd/threagile-rules.
+package rules
+
+import (
+	"sort"
+
+	"github.com/Otyg/threagile-rules/rules/accidentallogging"
+	"github.com/Otyg/threagile-rules/rules/alertingpath"
+	"github.com/Otyg/threagile-rules/rules/credentialvault"
+	"github.com/Otyg/threagile-rules/rules/insecurehandling"
+	"github.com/Otyg/threagile-rules/rules/logtampering"
+	"github.com/Otyg/threagile-rules/rules/missingaudit"
+	"github.com/Otyg/threagile-rules/rules/missingmonitoring"
+	"github.com/Otyg/threagile-rules/rules/misspelledtag"
+	"github.com/Otyg/threagile-rules/rules/monitoringprotection"
+	"github.com/Otyg/threagile-rules/rules/postquantum"
+	"github.com/Otyg/threagile-rules/rules/privilegeduser"
+	"github.com/Otyg/threagile-rules/rules/secretssprawl"
+	"github.com/Otyg/threagile-rules/rules/securecommunication"
+	"github.com/Otyg/threagile-rules/rules/vaultreachability"
+	"github.com/Otyg/threagile-rules/rules/weakcrypto"
+	"github.com/threagile/threagile/model"
+)
+
+// All returns every rule, sorted by category id.
+func All() []model.CustomRiskRule {
+	all := []model.CustomRiskRule{
+		accidentallogging.Rule(""),
+		alertingpath.Rule(""),
+		credentialvault.Rule(""),
+		insecurehandling.Rule(""),
+		logtampering.Rule(""),
+		missingaudit.Rule(""),
+		missingmonitoring.Rule(""),
+		misspelledtag.Rule(""),
+		monitoringprotection.Rule(""),
+		postquantum.Rule(""),
+		privilegeduser.Rule(""),
+		secretssprawl.Rule(""),
+		securecommunication.Rule(""),
+		vaultreachability.Rule(""),
+		weakcrypto.Rule(""),
+	}
+	sort.Slice(all, func(i, j int) bool {
+		return all[i].Category().Id < all[j].Category().Id
+	})
+	return all
+}
+
+func init() {
+	misspelledtag.KnownRules = All
+}
+
+// ById returns the rule with the given category id.
+func ById(id string) (model.CustomRiskRule, bool) {
+	for _, rule := range All() {
+		if rule.Category().Id == id {
+			return rule, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,125 @@
+// Package secretssprawl implements the secrets-sprawl risk rule.
+package secretssprawl
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Otyg/threagile-rules/internal/rulekit"
+	"github.com/Otyg/threagile-rules/rules/credentialvault"
+	"github.com/threagile/threagile/model"
+)
+
+type Rule string
+
+func (r Rule) Category() model.RiskCategory {
+	return model.RiskCategory{
+		Id:                         "secrets-sprawl",
+		Title:                      "Secrets Sprawl",
+		Description:                "A single credential copied into many technical assets, or across trust boundaries, is a blast-radius problem even if each holder protects it well: one leak compromises every service sharing it.",
+		Impact:                     "If the shared credential leaks from any holder an attacker can act as all of them, and rotating it requires changing every holder at once.",
+		ASVS:                       "v4.0.2-2.10 - Service Authentication Requirements, v4.0.2-6.4 - Secret Management",
+		CheatSheet:                 "https://cheatsheetseries.owasp.org/cheatsheets/Secrets_Management_Cheat_Sheet.html",
+		Action:                     "Secret management",
+		Mitigation:                 "Issue per-service credentials, ideally short-lived ones from a vault, so that every holder has its own secret which can be revoked and rotated independently.",
+		Check:                      "Does every service have its own credential?",
+		Function:                   model.Architecture,
+		STRIDE:                     model.ElevationOfPrivilege,
+		DetectionLogic:             "Data assets tagged as credentials, by the tags of " + credentialvault.Rule("").Category().Id + ", stored or processed by more in-scope technical assets, other than vaults, or across more trust boundaries than allowed.",
+		RiskAssessment:             "One risk per credential. The impact depends on the confidentiality of the credential, with a minimum of medium. The likelihood is raised when both the number of holders and of trust boundaries exceed their thresholds.",
+		FalsePositives:             "Credentials deliberately shared by replicas of the same service, which can be accepted after review.",
+		ModelFailurePossibleReason: false,
+		CWE:                        798,
+	}
+}
+
+func (r Rule) SupportedTags() []string {
+	return []string{}
+}
+
+func (r Rule) GenerateRisks() []model.Risk {
+	risks := make([]model.Risk, 0)
+	settings := settings()
+	for _, data := range credentialvault.Credentials() {
+		holders := holdersOf(data)
+		boundaries := trustBoundaries(holders)
+		tooManyHolders := len(holders) > settings.MaxHolders
+		tooManyBoundaries := len(boundaries) > settings.MaxTrustBoundaries
+		if !tooManyHolders && !tooManyBoundaries {
+			continue
+		}
+		likelihood := settings.Likelihood.RiskExploitationLikelihood
+		if tooManyHolders && tooManyBoundaries {
+			likelihood = rulekit.RaiseLikelihood(likelihood)
+		}
+		impact := rulekit.MaxImpact(model.MediumImpact, rulekit.ImpactFromConfidentiality(data.Confidentiality))
+		risks = append(risks, r.createRisk(data, holders, boundaries, likelihood, impact))
+	}
+	return risks
+}
+
+// holdersOf returns the in-scope technical assets, other than vaults, storing
+// or processing the credential, sorted by id.
+func holdersOf(data model.DataAsset) []model.TechnicalAsset {
+	result := make([]model.TechnicalAsset, 0)
+	for _, id := range model.SortedTechnicalAssetIDs() {
+		technicalAsset := model.ParsedModelRoot.TechnicalAssets[id]
+		if technicalAsset.OutOfScope || technicalAsset.Technology == model.Vault {
+			continue
+		}
+		if model.Contains(technicalAsset.DataAssetsStored, data.Id) || model.Contains(technicalAsset.DataAssetsProcessed, data.Id) {
+			result = append(result, technicalAsset)
+		}
+	}
+	return result
+}
+
+// trustBoundaries returns the titles of the trust boundaries directly
+// containing the holders, sorted, each boundary once by its id. Holders
+// outside of any trust boundary count as one boundary of their own.
+func trustBoundaries(holders []model.TechnicalAsset) []string {
+	titles := make(map[string]string)
+	for _, holder := range holders {
+		if boundary, ok := model.DirectContainingTrustBoundaryMappedByTechnicalAssetId[holder.Id]; ok {
+			titles[boundary.Id] = boundary.Title
+		} else {
+			titles[""] = "no trust boundary"
+		}
+	}
+	result := make([]string, 0, len(titles))
+	for _, title := range titles {
+		result = append(result, title)
+	}
+	sort.Strings(result)
+	return result
+}
+
+func (r Rule) createRisk(data model.DataAsset, holders []model.TechnicalAsset, boundaries []string, likelihood model.RiskExploitationLikelihood, impact model.RiskExploitationImpact) model.Risk {
+	titles := make([]string, 0, len(holders))
+	ids := make([]string, 0, len(holders))
+	for _, holder := range holders {
+		titles = append(titles, holder.Title)
+		ids = append(ids, holder.Id)
+	}
+	title := "<b>Secrets Sprawl</b> of <b>" + data.Title + "</b>: held by " + count(len(holders), "asset") + " (<b>" +
+		strings.Join(titles, "</b>, <b>") + "</b>) across " + count(len(boundaries), "trust boundary") + " (" + strings.Join(boundaries, ", ") + ")"
+	return rulekit.NewRisk(r.Category(), title).
+		Rating(likelihood, impact).
+		TechnicalAsset(holders[0].Id).
+		DataAsset(data.Id).
+		DataBreach(model.Possible, ids...).
+		IdentifiedBy(data.Id).
+		Build()
+}
+
+func count(n int, noun string) string {
+	switch {
+	case n == 1:
+		return "1 " + noun
+	case strings.HasSuffix(noun, "y"):
+		return strconv.Itoa(n) + " " + strings.TrimSuffix(noun, "y") + "ies"
+	default:
+		return strconv.Itoa(n) + " " + noun + "s"
+	}
+}
@@ -0,0 +1,28 @@
+package secretssprawl
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Otyg/threagile-rules/internal/ruletest"
+	"github.com/threagile/threagile/model"
+)
+
+func TestGenerateRisks(t *testing.T) {
+	ruletest.RunFixtures(t, Rule(""))
+}
+
+func TestTrustBoundariesWithSameTitle(t *testing.T) {
+	model.DirectContainingTrustBoundaryMappedByTechnicalAssetId = map[string]model.TrustBoundary{
+		"eu-service":   {Id: "private-subnet-eu", Title: "Private Subnet"},
+		"us-service":   {Id: "private-subnet-us", Title: "Private Subnet"},
+		"eu-replica":   {Id: "private-subnet-eu", Title: "Private Subnet"},
+		"batch-worker": {Id: "batch", Title: "Batch"},
+	}
+	holders := []model.TechnicalAsset{{Id: "eu-service"}, {Id: "us-service"}, {Id: "eu-replica"}, {Id: "batch-worker"}, {Id: "outside"}}
+
+	expected := []string{"Batch", "Private Subnet", "Private Subnet", "no trust boundary"}
+	if actual := trustBoundaries(holders); strings.Join(actual, "|") != strings.Join(expected, "|") {
+		t.Errorf("expected %q, got %q", expected, actual)
+	}
+}
@@ -0,0 +1,43 @@
+package secretssprawl
+
+import (
+	"fmt"
+
+	"github.com/Otyg/threagile-rules/internal/config"
+	"github.com/threagile/threagile/model"
+)
+
+// Settings of the rule. A credential held by more than MaxHolders technical
+// assets, or spread over more than MaxTrustBoundaries trust boundaries, has
+// sprawled; Likelihood rates it when only one of the thresholds is exceeded.
+type Settings struct {
+	MaxHolders         int               `yaml:"max-holders"`
+	MaxTrustBoundaries int               `yaml:"max-trust-boundaries"`
+	Likelihood         config.Likelihood `yaml:"likelihood"`
+}
+
+func defaultSettings() config.Settings {
+	return &Settings{
+		MaxHolders:         2,
+		MaxTrustBoundaries: 1,
+		Likelihood:         config.Likelihood{RiskExploitationLikelihood: model.Likely},
+	}
+}
+
+func (s *Settings) Validate() error {
+	if s.MaxHolders < 1 {
+		return fmt.Errorf("max-holders (%d) must be at least 1", s.MaxHolders)
+	}
+	if s.MaxTrustBoundaries < 1 {
+		return fmt.Errorf("max-trust-boundaries (%d) must be at least 1", s.MaxTrustBoundaries)
+	}
+	return nil
+}
+
+func settings() *Settings {
+	return config.Get(Rule("").Category().Id).(*Settings)
+}
+
+func init() {
+	config.Register(Rule("").Category().Id, defaultSettings)
+}
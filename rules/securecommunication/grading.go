@@ -0,0 +1,163 @@
+package securecommunication
+
+import (
+	"strings"
+
+	"github.com/Otyg/threagile-rules/internal/rulekit"
+	"github.com/threagile/threagile/model"
+)
+
+// tlsVersions rates the tls:<version> tags, a link supporting several
+// versions is rated by the weakest since a downgrade is possible.
+var tlsVersions = []struct {
+	tag                   string
+	likelihood            model.RiskExploitationLikelihood
+	dataBreachProbability model.DataBreachProbability
+	reason                string
+}{
+	{"tls:1.0", model.VeryLikely, model.Probable, "deprecated TLS 1.0"},
+	{"tls:1.1", model.VeryLikely, model.Probable, "deprecated TLS 1.1"},
+	{"tls:1.2", model.Unlikely, model.Possible, "TLS 1.2"},
+	{"tls:1.3", model.Unlikely, model.Improbable, "TLS 1.3"},
+}
+
+// ciphers maps the cipher:<name> tags to the algorithm catalogue of package
+// rulekit, which grades them the same way as the crypto: and hash: tags. A
+// link accepting a weak or broken cipher can be downgraded to it, strong
+// ciphers are supported so they can be documented in the model and do not
+// change the grade.
+var ciphers = []struct {
+	tag       string
+	algorithm string
+}{
+	{"cipher:null", "crypto:null"},
+	{"cipher:export", "crypto:export"},
+	{"cipher:rc4", "crypto:rc4"},
+	{"cipher:des", "crypto:des"},
+	{"cipher:3des", "crypto:3des"},
+	{"cipher:md5", "hash:md5"},
+	{"cipher:aes-128-cbc", "crypto:aes-128-cbc"},
+	{"cipher:aes-256-cbc", "crypto:aes-256-cbc"},
+	{"cipher:sha1", "hash:sha-1"},
+	{"cipher:rsa-key-exchange", "crypto:rsa-key-exchange"},
+	{"cipher:aes-128-gcm", "crypto:aes-128-gcm"},
+	{"cipher:aes-256-gcm", "crypto:aes-256-gcm"},
+	{"cipher:chacha20-poly1305", "crypto:chacha20-poly1305"},
+}
+
+const mutualTLSTag = "mtls"
+
+// unspecifiedProtocols are encrypted without saying how.
+var unspecifiedProtocols = []model.Protocol{model.BINARY_encrypted, model.TEXT_encrypted, model.IIOP_encrypted, model.JRMP_encrypted}
+
+var tagDescriptions = map[string]string{
+	"tls:1.0":    "The link accepts TLS 1.0, which is deprecated",
+	"tls:1.1":    "The link accepts TLS 1.1, which is deprecated",
+	"tls:1.2":    "The link accepts TLS 1.2",
+	"tls:1.3":    "The link accepts TLS 1.3",
+	mutualTLSTag: "Both ends of the link authenticate with certificates (mutual TLS)",
+}
+
+func supportedTags() []string {
+	tags := make([]string, 0)
+	for _, version := range tlsVersions {
+		tags = append(tags, version.tag)
+	}
+	for _, cipher := range ciphers {
+		tags = append(tags, cipher.tag)
+	}
+	return append(tags, mutualTLSTag)
+}
+
+// describeCipher describes a cipher:<name> tag by its catalogue entry, or
+// returns an empty string.
+func describeCipher(tag string) string {
+	if algorithm, ok := cipherAlgorithm(tag); ok {
+		return "The link accepts " + algorithm.Describe()
+	}
+	return ""
+}
+
+func cipherAlgorithm(tag string) (rulekit.Algorithm, bool) {
+	for _, cipher := range ciphers {
+		if strings.EqualFold(cipher.tag, tag) {
+			return rulekit.LookupAlgorithm(cipher.algorithm)
+		}
+	}
+	return rulekit.Algorithm{}, false
+}
+
+// grade is the rating of an encrypted link and the reason for it.
+type grade struct {
+	likelihood            model.RiskExploitationLikelihood
+	dataBreachProbability model.DataBreachProbability
+	reason                string
+}
+
+// gradeLink rates an encrypted link by its protocol and tags. Without hints
+// the link keeps the Unlikely rating the rule has always used.
+func gradeLink(commLink model.CommunicationLink) grade {
+	result := grade{model.Unlikely, model.Possible, "TLS version not modelled"}
+	if isUnspecified(commLink.Protocol) {
+		result = grade{model.Likely, model.Possible, "encryption of " + commLink.Protocol.String() + " not specified"}
+	} else if commLink.Protocol == model.SSH || commLink.Protocol == model.SSH_tunnel || commLink.Protocol == model.SFTP || commLink.Protocol == model.SCP {
+		result.reason = commLink.Protocol.String()
+	}
+	for i := len(tlsVersions) - 1; i >= 0; i-- {
+		// the weakest tagged version is seen last and wins
+		version := tlsVersions[i]
+		if commLink.IsTaggedWithAny(version.tag) {
+			result = grade{version.likelihood, version.dataBreachProbability, version.reason}
+		}
+	}
+	if cipher, algorithm, ok := weakestCipher(commLink); ok {
+		switch algorithm.Strength {
+		case rulekit.BrokenAlgorithm:
+			result = worse(result, grade{model.VeryLikely, model.Probable, "broken cipher " + cipher})
+		case rulekit.WeakAlgorithm:
+			result = worse(result, grade{model.Likely, model.Possible, "weak cipher " + cipher})
+		}
+	}
+	if commLink.IsTaggedWithAny(mutualTLSTag) {
+		result.likelihood = rulekit.LowerLikelihood(result.likelihood)
+		result.reason += " with mutual TLS"
+	}
+	return result
+}
+
+// worse returns the grade with the higher likelihood, joining the reasons
+// when the hint raises it.
+func worse(current, hint grade) grade {
+	if hint.likelihood <= current.likelihood {
+		return current
+	}
+	if current.dataBreachProbability > hint.dataBreachProbability {
+		hint.dataBreachProbability = current.dataBreachProbability
+	}
+	hint.reason = current.reason + ", " + hint.reason
+	return hint
+}
+
+// weakestCipher returns the name and catalogue entry of the weakest cipher
+// the link is tagged with, the first one in the order of ciphers on a tie.
+func weakestCipher(commLink model.CommunicationLink) (string, rulekit.Algorithm, bool) {
+	name, weakest, found := "", rulekit.Algorithm{}, false
+	for _, cipher := range ciphers {
+		if !commLink.IsTaggedWithAny(cipher.tag) {
+			continue
+		}
+		if algorithm, ok := rulekit.LookupAlgorithm(cipher.algorithm); ok && (!found || algorithm.Strength > weakest.Strength) {
+			name, weakest, found = strings.TrimPrefix(cipher.tag, "cipher:"), algorithm, true
+		}
+	}
+	return name, weakest, found
+}
+
+func isUnspecified(protocol model.Protocol) bool {
+	for _, unspecified := range unspecifiedProtocols {
+		if protocol == unspecified {
+			return true
+		}
+	}
+	return false
+}
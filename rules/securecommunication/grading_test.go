@@ -0,0 +1,11 @@
+package securecommunication
+
+import "testing"
+
+func TestCiphersAreInCatalogue(t *testing.T) {
+	for _, cipher := range ciphers {
+		if _, ok := cipherAlgorithm(cipher.tag); !ok {
+			t.Errorf("%s maps to %s, which is missing in the algorithm catalogue", cipher.tag, cipher.algorithm)
+		}
+	}
+}
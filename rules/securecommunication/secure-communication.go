@@ -0,0 +1,86 @@
+// Package securecommunication implements the use-of-weak-cryptography-in-transit risk rule.
+package securecommunication
+
+import (
+	"sort"
+
+	"github.com/Otyg/threagile-rules/internal/rulekit"
+	"github.com/threagile/threagile/model"
+)
+
+type Rule string
+
+func (r Rule) Category() model.RiskCategory {
+	return model.RiskCategory{
+		Id:                         "use-of-weak-cryptography-in-transit",
+		Title:                      "Use Of Weak Cryptography in transit",
+		Description:                "To ensure confidentiality during transit strong encryption must be used; weak, broken or soon to be deprecated algorithms must be avoided and recommended key lengths must be applied.",
+		Impact:                     "Weak cryptography can result in information disclosure and a false sense of security.",
+		ASVS:                       "v4.0.3-9.X - Communication",
+		CheatSheet:                 "https://cheatsheetseries.owasp.org/cheatsheets/Transport_Layer_Protection_Cheat_Sheet.html",
+		Action:                     "Cryptography",
+		Mitigation:                 "Ensure to use algoritms, modes and libraries that has been vetted and proven by industry and/or governments and follow recommendations and guidelines.",
+		Check:                      "Referenced ASVS chapters and cheat sheets",
+		Function:                   model.Operations,
+		STRIDE:                     model.InformationDisclosure,
+		DetectionLogic:             "Every encrypted communication link, graded by its protocol and the tls:<version>, cipher:<name> and mtls tags.",
+		RiskAssessment:             "Impact is based on the confidentiality score of data sent or recieved. Likelihood is raised for deprecated TLS versions, broken or weak ciphers and encryption that is not specified, and lowered for mutual TLS.",
+		FalsePositives:             "None",
+		ModelFailurePossibleReason: false,
+		CWE:                        327,
+	}
+}
+func (r Rule) SupportedTags() []string {
+	return supportedTags()
+}
+
+func (r Rule) DescribeTag(tag string) string {
+	if description := describeCipher(tag); len(description) > 0 {
+		return description
+	}
+	return rulekit.DescribeTag(tag, tagDescriptions)
+}
+
+func (r Rule) GenerateRisks() []model.Risk {
+	risks := make([]model.Risk, 0)
+	for _, commLink := range encryptedLinks() {
+		var mostCriticalDataAsset model.DataAsset
+		for _, data := range append(commLink.DataAssetsSentSorted(), commLink.DataAssetsReceivedSorted()...) {
+			if len(mostCriticalDataAsset.Id) == 0 || data.Confidentiality > mostCriticalDataAsset.Confidentiality {
+				mostCriticalDataAsset = data
+			}
+		}
+		exploitationImpact := rulekit.ImpactFromConfidentiality(mostCriticalDataAsset.Confidentiality)
+		risks = append(risks, r.createRisk(commLink, mostCriticalDataAsset, exploitationImpact, gradeLink(commLink)))
+	}
+	return risks
+}
+
+// encryptedLinks returns the encrypted communication links sorted by id.
+func encryptedLinks() []model.CommunicationLink {
+	links := make([]model.CommunicationLink, 0)
+	for _, id := range model.SortedTechnicalAssetIDs() {
+		for _, commLink := range model.ParsedModelRoot.TechnicalAssets[id].CommunicationLinks {
+			if commLink.Protocol.IsEncrypted() {
+				links = append(links, commLink)
+			}
+		}
+	}
+	sort.Slice(links, func(i, j int) bool {
+		return links[i].Id < links[j].Id
+	})
+	return links
+}
+
+func (r Rule) createRisk(commLink model.CommunicationLink, dataAsset model.DataAsset, exploitationImpact model.RiskExploitationImpact, grade grade) model.Risk {
+	source := model.ParsedModelRoot.TechnicalAssets[commLink.SourceId]
+	title := rulekit.TitleAt("Use of weak cryptography in transit", source) + " over <b>" + commLink.Title + "</b>: " + grade.reason
+	return rulekit.NewRisk(r.Category(), title).
+		Rating(grade.likelihood, exploitationImpact).
+		TechnicalAsset(source.Id).
+		CommunicationLink(commLink.Id).
+		DataAsset(dataAsset.Id).
+		DataBreach(grade.dataBreachProbability, source.Id).
+		IdentifiedBy(commLink.Id).
+		Build()
+}
@@ -0,0 +1,34 @@
+package vaultreachability
+
+import (
+	"github.com/Otyg/threagile-rules/internal/config"
+	"github.com/threagile/threagile/model"
+)
+
+// Settings of the rule. Assets tagged with any of AgentTags, such as a
+// sidecar or a vault agent, fetch credentials from the vault on behalf of the
+// assets linking to them or sharing their runtime. Likelihood rates consumers
+// without any link to the vault.
+type Settings struct {
+	AgentTags  []string          `yaml:"agent-tags"`
+	Likelihood config.Likelihood `yaml:"likelihood"`
+}
+
+func defaultSettings() config.Settings {
+	return &Settings{
+		AgentTags:  []string{"vault-agent", "secrets-sidecar"},
+		Likelihood: config.Likelihood{RiskExploitationLikelihood: model.Likely},
+	}
+}
+
+func (s *Settings) Validate() error {
+	return config.ValidateTags("agent-tags", s.AgentTags)
+}
+
+func settings() *Settings {
+	return config.Get(Rule("").Category().Id).(*Settings)
+}
+
+func init() {
+	config.Register(Rule("").Category().Id, defaultSettings)
+}
@@ -0,0 +1,227 @@
+// Package vaultreachability implements the vault-reachability risk rule.
+package vaultreachability
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/Otyg/threagile-rules/internal/rulekit"
+	"github.com/Otyg/threagile-rules/rules/credentialvault"
+	"github.com/threagile/threagile/model"
+)
+
+type Rule string
+
+func (r Rule) Category() model.RiskCategory {
+	return model.RiskCategory{
+		Id:                         "vault-reachability",
+		Title:                      "Vault Reachability",
+		Description:                "Technical assets using credentials should fetch them from the vault, directly or through a sidecar or agent. Without a link to the vault the secrets are injected some other way, e.g. by configuration files or environment variables, bypassing the vault.",
+		Impact:                     "Credentials injected outside of the vault are not covered by its access control, auditing and rotation, and credentials fetched over an insecure link can be intercepted or the vault impersonated.",
+		ASVS:                       "v4.0.2-6.4 - Secret Management, v4.0.2-9.2 - Server Communications Security Requirements",
+		CheatSheet:                 "https://cheatsheetseries.owasp.org/cheatsheets/Secrets_Management_Cheat_Sheet.html",
+		Action:                     "Secret management",
+		Mitigation:                 "Fetch credentials from the vault at runtime, directly or through a vault agent or sidecar, over authenticated and encrypted links.",
+		Check:                      "Do all assets using credentials fetch them from the vault over an authenticated and encrypted link?",
+		Function:                   model.Architecture,
+		STRIDE:                     model.InformationDisclosure,
+		DetectionLogic:             "In models with a vault, in-scope technical assets processing data assets tagged as credentials, by the tags of " + credentialvault.Rule("").Category().Id + ", without a communication link to a vault, either directly or through an asset tagged as agent which they link to or share a runtime with. The links used to reach the vault are checked for authentication and encryption, process-local links are not required to be encrypted.",
+		RiskAssessment:             "One risk per consumer without a link to the vault and one per insecure link to the vault. The impact depends on the confidentiality of the credentials, with a minimum of medium. Insecure links are likely, or very likely when both unauthenticated and unencrypted.",
+		FalsePositives:             "Credentials injected by the deployment platform from the vault, e.g. by an operator, which can be accepted after review.",
+		ModelFailurePossibleReason: true,
+		CWE:                        522,
+	}
+}
+
+func (r Rule) SupportedTags() []string {
+	return settings().AgentTags
+}
+
+var tagDescriptions = map[string]string{
+	"vault-agent":     "The asset fetches credentials from the vault on behalf of the assets linking to it or sharing its runtime",
+	"secrets-sidecar": "The asset is a sidecar fetching credentials from the vault for the assets in its shared runtime",
+}
+
+func (r Rule) DescribeTag(tag string) string {
+	return rulekit.DescribeTag(tag, tagDescriptions)
+}
+
+// vaultLink is a link used to reach the vault, rated by the most sensitive
+// credentials fetched over it.
+type vaultLink struct {
+	model.CommunicationLink
+	impact    model.RiskExploitationImpact
+	consumers []string
+}
+
+func (r Rule) GenerateRisks() []model.Risk {
+	risks := make([]model.Risk, 0)
+	if !hasVault() {
+		return risks
+	}
+	settings := settings()
+	linksById := make(map[string]*vaultLink)
+	for _, id := range model.SortedTechnicalAssetIDs() {
+		consumer := model.ParsedModelRoot.TechnicalAssets[id]
+		if consumer.OutOfScope || consumer.Technology == model.Vault {
+			continue
+		}
+		credentials := credentialsProcessed(consumer)
+		if len(credentials) == 0 {
+			continue
+		}
+		impact := model.MediumImpact
+		for _, data := range credentials {
+			impact = rulekit.MaxImpact(impact, rulekit.ImpactFromConfidentiality(data.Confidentiality))
+		}
+		path := pathToVault(consumer, settings)
+		if len(path) == 0 {
+			risks = append(risks, r.createMissingRisk(consumer, credentials, impact, settings.Likelihood.RiskExploitationLikelihood))
+			continue
+		}
+		for _, commLink := range path {
+			link, ok := linksById[commLink.Id]
+			if !ok {
+				link = &vaultLink{CommunicationLink: commLink, impact: impact}
+				linksById[commLink.Id] = link
+			}
+			link.impact = rulekit.MaxImpact(link.impact, impact)
+			if !model.Contains(link.consumers, consumer.Title) {
+				link.consumers = append(link.consumers, consumer.Title)
+			}
+		}
+	}
+	ids := make([]string, 0, len(linksById))
+	for id := range linksById {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		link := linksById[id]
+		if likelihood, reasons := rate(link.CommunicationLink); len(reasons) > 0 {
+			risks = append(risks, r.createInsecureRisk(*link, likelihood, reasons))
+		}
+	}
+	return risks
+}
+
+func hasVault() bool {
+	for _, technicalAsset := range model.ParsedModelRoot.TechnicalAssets {
+		if technicalAsset.Technology == model.Vault {
+			return true
+		}
+	}
+	return false
+}
+
+// credentialsProcessed returns the credentials processed by the technical
+// asset, sorted by title.
+func credentialsProcessed(technicalAsset model.TechnicalAsset) []model.DataAsset {
+	result := make([]model.DataAsset, 0)
+	for _, data := range credentialvault.Credentials() {
+		if model.Contains(technicalAsset.DataAssetsProcessed, data.Id) {
+			result = append(result, data)
+		}
+	}
+	return result
+}
+
+// pathToVault returns the links the consumer reaches the vault over: its own
+// links to vaults, or those of the agents it links to or shares a runtime
+// with, together with the link to the agent. It is empty when the vault is
+// not reachable.
+func pathToVault(consumer model.TechnicalAsset, settings *Settings) []model.CommunicationLink {
+	result := linksToVault(consumer)
+	for _, commLink := range consumer.CommunicationLinksSorted() {
+		agent := model.ParsedModelRoot.TechnicalAssets[commLink.TargetId]
+		if !agent.IsTaggedWithAny(settings.AgentTags...) {
+			continue
+		}
+		if agentLinks := linksToVault(agent); len(agentLinks) > 0 {
+			result = append(append(result, commLink), agentLinks...)
+		}
+	}
+	if sharedRuntime, ok := model.DirectContainingSharedRuntimeMappedByTechnicalAssetId[consumer.Id]; ok {
+		running := append([]string{}, sharedRuntime.TechnicalAssetsRunning...)
+		sort.Strings(running)
+		for _, id := range running {
+			agent := model.ParsedModelRoot.TechnicalAssets[id]
+			if id != consumer.Id && agent.IsTaggedWithAny(settings.AgentTags...) {
+				result = append(result, linksToVault(agent)...)
+			}
+		}
+	}
+	return result
+}
+
+func linksToVault(technicalAsset model.TechnicalAsset) []model.CommunicationLink {
+	result := make([]model.CommunicationLink, 0)
+	for _, commLink := range technicalAsset.CommunicationLinksSorted() {
+		if model.ParsedModelRoot.TechnicalAssets[commLink.TargetId].Technology == model.Vault {
+			result = append(result, commLink)
+		}
+	}
+	return result
+}
+
+// rate returns the likelihood of credentials being intercepted on the link,
+// or the vault impersonated, and the weaknesses making it possible.
+func rate(commLink model.CommunicationLink) (model.RiskExploitationLikelihood, []string) {
+	likelihood := model.Unlikely
+	reasons := make([]string, 0)
+	if commLink.Authentication == model.NoneAuthentication {
+		likelihood = model.Likely
+		reasons = append(reasons, "unauthenticated")
+	}
+	if !commLink.Protocol.IsEncrypted() && !commLink.Protocol.IsProcessLocal() {
+		if likelihood == model.Likely {
+			likelihood = model.VeryLikely
+		} else {
+			likelihood = model.Likely
+		}
+		reasons = append(reasons, "unencrypted")
+	}
+	return likelihood, reasons
+}
+
+func (r Rule) createMissingRisk(consumer model.TechnicalAsset, credentials []model.DataAsset, impact model.RiskExploitationImpact, likelihood model.RiskExploitationLikelihood) model.Risk {
+	titles := make([]string, 0, len(credentials))
+	for _, data := range credentials {
+		titles = append(titles, data.Title)
+	}
+	title := rulekit.TitleAt("Missing Vault Link", consumer) + " processing <b>" + strings.Join(titles, "</b>, <b>") + "</b>"
+	return rulekit.NewRisk(r.Category(), title).
+		Rating(likelihood, impact).
+		TechnicalAsset(consumer.Id).
+		DataAsset(mostConfidential(credentials).Id).
+		DataBreach(model.Possible, consumer.Id).
+		IdentifiedBy(consumer.Id).
+		Build()
+}
+
+// mostConfidential returns the credential with the highest confidentiality,
+// the one with the lowest id on ties.
+func mostConfidential(credentials []model.DataAsset) model.DataAsset {
+	result := credentials[0]
+	for _, data := range credentials[1:] {
+		if data.Confidentiality > result.Confidentiality ||
+			(data.Confidentiality == result.Confidentiality && data.Id < result.Id) {
+			result = data
+		}
+	}
+	return result
+}
+
+func (r Rule) createInsecureRisk(link vaultLink, likelihood model.RiskExploitationLikelihood, reasons []string) model.Risk {
+	source := model.ParsedModelRoot.TechnicalAssets[link.SourceId]
+	target := model.ParsedModelRoot.TechnicalAssets[link.TargetId]
+	title := "<b>Insecure Vault Link</b> from <b>" + source.Title + "</b> to <b>" + target.Title + "</b> over <b>" + link.Title + "</b> used by <b>" +
+		strings.Join(link.consumers, "</b>, <b>") + "</b>: " + strings.Join(reasons, ", ")
+	return rulekit.NewRisk(r.Category(), title).
+		Rating(likelihood, link.impact).
+		TechnicalAsset(source.Id).
+		CommunicationLink(link.Id).
+		DataBreach(model.Possible, source.Id, target.Id).
+		IdentifiedBy(link.Id).
+		Build()
+}
@@ -0,0 +1,29 @@
+package weakcrypto
+
+import (
+	"github.com/Otyg/threagile-rules/internal/config"
+)
+
+// Settings of the rule. Encrypted assets without any algorithm tag are only
+// reported, as algorithm not modelled, with ReportUnmodelled.
+type Settings struct {
+	ReportUnmodelled bool `yaml:"report-unmodelled"`
+}
+
+func defaultSettings() config.Settings {
+	return &Settings{
+		ReportUnmodelled: false,
+	}
+}
+
+func (s *Settings) Validate() error {
+	return nil
+}
+
+func settings() *Settings {
+	return config.Get(Rule("").Category().Id).(*Settings)
+}
+
+func init() {
+	config.Register(Rule("").Category().Id, defaultSettings)
+}
@@ -0,0 +1,133 @@
+// Package weakcrypto implements the use-of-weak-cryptograhpy-at-rest risk rule.
+package weakcrypto
+
+import (
+	"github.com/Otyg/threagile-rules/internal/rulekit"
+	"github.com/threagile/threagile/model"
+)
+
+type Rule string
+
+func (r Rule) Category() model.RiskCategory {
+	return model.RiskCategory{
+		Id:                         "use-of-weak-cryptograhpy-at-rest",
+		Title:                      "Use Of Weak Cryptography At Rest",
+		Description:                "To avoid weak cryptography ensure to use algoritms, modes and libraries that has been vetted and proven by industry and/or governments.",
+		Impact:                     "Weak cryptography can result in information disclosure and a false sense of security.",
+		ASVS:                       "v4.0.3-6.2 - Stored cryptography: Algorithms",
+		CheatSheet:                 "https://cheatsheetseries.owasp.org/cheatsheets/Cryptographic_Storage_Cheat_Sheet.html",
+		Action:                     "Cryptography",
+		Mitigation:                 "Ensure to use algoritms, modes and libraries that has been vetted and proven by industry and/or governments.",
+		Check:                      "Referenced ASVS chapters and cheat sheets",
+		Function:                   model.Development,
+		STRIDE:                     model.InformationDisclosure,
+		DetectionLogic:             "Encrypted technical assets and data assets tagged with crypto:<algorithm> or hash:<algorithm>, where the weakest algorithm of the asset and the stored data is weak, broken or unknown. Encrypted assets without any algorithm tag are only reported when report-unmodelled is set.",
+		RiskAssessment:             "Impact is based on the confidentiality score of stored data and likelihood on how broken the algorithm is. Transparent encryption lowers the likelihood, keys per end user lower the impact.",
+		FalsePositives:             "None",
+		ModelFailurePossibleReason: false,
+		CWE:                        327,
+	}
+}
+func (r Rule) SupportedTags() []string {
+	return rulekit.AlgorithmTags()
+}
+
+// OpenTagPrefixes lets algorithms missing in the catalogue be tagged, they are
+// rated as unknown.
+func (r Rule) OpenTagPrefixes() []string {
+	return []string{rulekit.CryptoTagPrefix, rulekit.HashTagPrefix}
+}
+
+func (r Rule) DescribeTag(tag string) string {
+	return rulekit.DescribeAlgorithmTag(tag)
+}
+
+// finding is the rating of one data asset stored on a technical asset.
+type finding struct {
+	dataAsset             model.DataAsset
+	likelihood            model.RiskExploitationLikelihood
+	impact                model.RiskExploitationImpact
+	dataBreachProbability model.DataBreachProbability
+	reason                string
+}
+
+func (r Rule) GenerateRisks() []model.Risk {
+	risks := make([]model.Risk, 0)
+	settings := settings()
+	for _, id := range model.SortedTechnicalAssetIDs() {
+		techAsset := model.ParsedModelRoot.TechnicalAssets[id]
+		if techAsset.OutOfScope || techAsset.Technology.IsClient() {
+			continue
+		}
+		stored := techAsset.DataAssetsStoredSorted()
+		if len(stored) == 0 {
+			stored = []model.DataAsset{{}}
+		}
+		var worst *finding
+		for _, data := range stored {
+			current, weak := rate(techAsset, data, settings)
+			if weak && (worst == nil || current.likelihood > worst.likelihood ||
+				current.likelihood == worst.likelihood && current.impact > worst.impact) {
+				worst = &current
+			}
+		}
+		if worst != nil {
+			risks = append(risks, r.createRisk(techAsset, adjustForEncryptionStyle(*worst, techAsset.Encryption)))
+		}
+	}
+	return risks
+}
+
+// rate grades the weakest algorithm protecting the data asset on the
+// technical asset. Assets without algorithm tags are only rated when they are
+// encrypted and unmodelled algorithms are reported.
+func rate(techAsset model.TechnicalAsset, data model.DataAsset, settings *Settings) (finding, bool) {
+	result := finding{
+		dataAsset:             data,
+		likelihood:            model.Unlikely,
+		impact:                rulekit.ImpactFromConfidentiality(data.Confidentiality),
+		dataBreachProbability: model.Possible,
+	}
+	algorithm, tagged := rulekit.Weakest(rulekit.TaggedAlgorithms(techAsset.Tags, data.Tags))
+	switch {
+	case !tagged && (techAsset.Encryption == model.NoneEncryption || !settings.ReportUnmodelled):
+		return result, false
+	case !tagged:
+		result.reason = "algorithm not modelled"
+	case algorithm.Strength == rulekit.StrongAlgorithm:
+		return result, false
+	case algorithm.Strength == rulekit.UnknownAlgorithm:
+		result.reason = "unknown algorithm " + algorithm.Name()
+	case algorithm.Strength == rulekit.WeakAlgorithm:
+		result.likelihood = model.Likely
+		result.reason = "weak algorithm " + algorithm.Name()
+	case algorithm.Strength == rulekit.BrokenAlgorithm:
+		result.likelihood = model.VeryLikely
+		result.dataBreachProbability = model.Probable
+		result.reason = "broken algorithm " + algorithm.Name()
+	}
+	return result, true
+}
+
+// adjustForEncryptionStyle lowers the likelihood for transparent encryption,
+// which an attacker only faces with the storage media in hand, and the
+// impact for keys per end user, where one broken key exposes one user's data.
+func adjustForEncryptionStyle(finding finding, style model.EncryptionStyle) finding {
+	switch style {
+	case model.Transparent:
+		finding.likelihood = rulekit.LowerLikelihood(finding.likelihood)
+	case model.DataWithEnduserIndividualKey:
+		finding.impact = rulekit.LowerImpact(finding.impact)
+	}
+	return finding
+}
+
+func (r Rule) createRisk(technicalAsset model.TechnicalAsset, finding finding) model.Risk {
+	return rulekit.NewRisk(r.Category(), rulekit.TitleAt("Use of weak cryptography at rest", technicalAsset)+": "+finding.reason).
+		Rating(finding.likelihood, finding.impact).
+		TechnicalAsset(technicalAsset.Id).
+		DataAsset(finding.dataAsset.Id).
+		DataBreach(finding.dataBreachProbability, technicalAsset.Id).
+		IdentifiedBy(technicalAsset.Id).
+		Build()
+}